@@ -0,0 +1,262 @@
+// -*- coding: utf-8 -*-
+// aggregate.go
+// -----------------------------------------------------------------------------
+//
+// Started on <sáb 17-10-2026 01:57:33 (1792202253)>
+//
+
+package table
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ----------------------------------------------------------------------------
+// Aggregate
+// ----------------------------------------------------------------------------
+
+// Functions
+// ----------------------------------------------------------------------------
+
+// -- Private
+
+// return the result of applying the given function to all values. Functions
+// which are not defined over no values (i.e., the mean, minimum and maximum)
+// return an empty string instead
+func reduce(function Aggregation, values []float64) any {
+
+	switch function {
+	case AggregateCount:
+		return len(values)
+	case AggregateSum:
+		var sum float64
+		for _, value := range values {
+			sum += value
+		}
+		return sum
+	}
+
+	if len(values) == 0 {
+		return ""
+	}
+	result := values[0]
+	for _, value := range values[1:] {
+		switch function {
+		case AggregateMean:
+			result += value
+		case AggregateMin:
+			if value < result {
+				result = value
+			}
+		case AggregateMax:
+			if value > result {
+				result = value
+			}
+		}
+	}
+	if function == AggregateMean {
+		result /= float64(len(values))
+	}
+	return result
+}
+
+// Methods
+// ----------------------------------------------------------------------------
+
+// -- Private
+
+// return the result of this aggregate over the given values
+func (a Aggregate) compute(values []float64) any {
+
+	if a.Reduce != nil {
+		return a.Reduce(values)
+	}
+	return reduce(a.Function, values)
+}
+
+// verify that this aggregate can be computed over the given number of columns
+func (a Aggregate) verify(nbcolumns int) error {
+
+	if a.Column < 0 || a.Column >= nbcolumns {
+		return fmt.Errorf("The column %v used in an aggregate does not exist", a.Column)
+	}
+	if a.Reduce == nil && (a.Function < AggregateSum || a.Function > AggregateCount) {
+		return fmt.Errorf("Unknown aggregate function %v", a.Function)
+	}
+	return nil
+}
+
+// return the numeric values of the jcol-th column of all data rows from the
+// given one until the end row (which is not included). Footers are not
+// considered
+func (t *Table) getNumbers(init, end, jcol int) (values []float64) {
+
+	for i := init; i < end; i++ {
+		if t.isRule(i) || t.rows[i].footer {
+			continue
+		}
+		if value, ok := toNumber(getCellValue(t.cells[i][jcol])); ok {
+			values = append(values, value)
+		}
+	}
+	return
+}
+
+// return the first row aggregated by a footer located in the given row. Footers
+// of a block aggregate only the rows after the horizontal rule which precedes
+// the rules right before them, and other footers aggregate all rows
+func (t *Table) getFooterInit(irow int, block bool) int {
+
+	if !block {
+		return 0
+	}
+	i := irow - 1
+	for ; i >= 0 && t.isRule(i); i-- {
+	}
+	for ; i >= 0 && !t.isRule(i); i-- {
+	}
+	return i + 1
+}
+
+// return the first row aggregated by the subtotal located in the given row,
+// which is the first row of the multirow with the key of its group, shown in
+// the given column
+func (t *Table) getSubtotalInit(irow, key int) int {
+
+	if m := t.getMulticell(irow, key); m != nil {
+		return m.getRowInit()
+	}
+	return irow
+}
+
+// compute again the aggregates of all footers added with AddFooter and all
+// subtotals added with AddGroups, so that they are consistent with the current
+// contents of the table
+func (t *Table) updateFooters() {
+
+	for i := range t.cells {
+		if !t.rows[i].footer || t.isRule(i) {
+			continue
+		}
+		init := t.getFooterInit(i, t.rows[i].block)
+		if t.rows[i].subtotal {
+			init = t.getSubtotalInit(i, t.rows[i].key)
+		}
+		for j, item := range t.cells[i] {
+			if v, ok := item.(value); ok && v.aggregate != nil {
+				v.data = v.aggregate.compute(t.getNumbers(init, i, j))
+				v.text = t.formatValue(j, v.data)
+				t.cells[i][j] = v
+			}
+		}
+	}
+}
+
+// -- Public
+
+// AddFooter adds a horizontal rule and a new row to the bottom of the table
+// with the aggregates of the given columns, as described in the footer. If the
+// table already ends with a horizontal rule, it is replaced with the rule of
+// the footer and drawn again after the footer to close the table. The
+// aggregates are shown as ordinary values, so that they are formatted with the
+// formatter of their columns, if any, and they are computed again whenever the
+// table is modified, e.g., with SetCell or InsertRow. Rows added with
+// AddFooter are not aggregated by other footers, so that a footer with the
+// aggregates of all data rows can be added after footers with the aggregates
+// of different blocks.
+//
+// It returns an error if no aggregate is given, any column does not exist or
+// it is aggregated more than once, any aggregate function or the separator are
+// unknown, or there are multirows spanning rows which have not been added yet
+func (t *Table) AddFooter(footer Footer) error {
+
+	if len(footer.Aggregates) == 0 {
+		return errors.New("At least one aggregate must be given to add a footer")
+	}
+	aggregated := make([]bool, t.GetNbColumns())
+	for _, aggregate := range footer.Aggregates {
+		if err := aggregate.verify(t.GetNbColumns()); err != nil {
+			return err
+		}
+		if aggregated[aggregate.Column] {
+			return fmt.Errorf("The column %v is aggregated more than once", aggregate.Column)
+		}
+		aggregated[aggregate.Column] = true
+	}
+	var rule hrule
+	switch footer.Rule {
+	case Single:
+		rule = hrule(horizontal_single)
+	case Double:
+		rule = hrule(horizontal_double)
+	case Thick:
+		rule = hrule(horizontal_thick)
+	default:
+		return fmt.Errorf("Unknown separator %v", footer.Rule)
+	}
+	for j := 0; j < t.GetNbColumns(); j++ {
+		if t.hasMulticell(len(t.rows), j) {
+			return fmt.Errorf("The multicell in column %v spans rows which have not been added yet", j)
+		}
+	}
+
+	// compute the first row to aggregate, which is the one after the last
+	// horizontal rule if only the last block has to be aggregated
+	init := t.getFooterInit(len(t.rows), footer.Block)
+
+	// compute the contents of the footer: the label is shown in the first
+	// column which is not aggregated, and the rest of them are left empty
+	cells := make([]any, t.GetNbColumns())
+	for j := range cells {
+		cells[j] = ""
+	}
+	for j := range cells {
+		if !aggregated[j] {
+			cells[j] = footer.Label
+			break
+		}
+	}
+	for _, aggregate := range footer.Aggregates {
+		cells[aggregate.Column] = aggregate.compute(t.getNumbers(init, len(t.rows), aggregate.Column))
+	}
+
+	// tables which already end with a horizontal rule (e.g., a bottom rule) are
+	// not given a second one. Instead, it is replaced with the rule of the
+	// footer, and it is drawn again after the footer to close the table
+	var closing []formatter
+	if n := len(t.rows); n > 0 && t.isRule(n-1) && t.verifyMultirows(n-1) == nil {
+		closing = t.cells[n-1]
+		t.cells, t.rows = t.cells[:n-1], t.rows[:n-1]
+	}
+
+	// and add them to the table after the horizontal rule
+	if err := t.addRule(rule); err != nil {
+		return err
+	}
+	if err := t.AddRow(cells...); err != nil {
+		return err
+	}
+
+	// and record the aggregates of the footer so that they are computed again
+	// whenever the table changes
+	irow := len(t.rows) - 1
+	t.rows[irow].footer, t.rows[irow].block = true, footer.Block
+	for _, aggregate := range footer.Aggregates {
+		aggregate := aggregate
+		if v, ok := t.cells[irow][aggregate.Column].(value); ok {
+			v.aggregate = &aggregate
+			t.cells[irow][aggregate.Column] = v
+		}
+	}
+	if closing != nil {
+		t.cells = append(t.cells, closing)
+		t.rows = append(t.rows, row{height: 1})
+	}
+	return nil
+}
+
+// Local Variables:
+// mode:go
+// fill-column:80
+// End:
@@ -0,0 +1,207 @@
+// -*- coding: utf-8 -*-
+// aggregate_test.go
+// -----------------------------------------------------------------------------
+//
+// Started on <sáb 17-10-2026 01:57:33 (1792202253)>
+//
+
+package table
+
+import (
+	"fmt"
+	"testing"
+)
+
+func Test_reduce(t *testing.T) {
+
+	tests := []struct {
+		name     string
+		function Aggregation
+		values   []float64
+		want     any
+	}{
+		{name: "sum", function: AggregateSum, values: []float64{1, 2.5, 3}, want: 6.5},
+		{name: "sum-empty", function: AggregateSum, want: 0.0},
+		{name: "mean", function: AggregateMean, values: []float64{1, 2, 6}, want: 3.0},
+		{name: "mean-empty", function: AggregateMean, want: ""},
+		{name: "min", function: AggregateMin, values: []float64{4, -1, 3}, want: -1.0},
+		{name: "max", function: AggregateMax, values: []float64{4, -1, 3}, want: 4.0},
+		{name: "max-empty", function: AggregateMax, want: ""},
+		{name: "count", function: AggregateCount, values: []float64{4, -1, 3}, want: 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := reduce(tt.function, tt.values); got != tt.want {
+				t.Errorf("reduce() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTable_AddFooter(t *testing.T) {
+
+	tests := []struct {
+		name    string
+		footers []Footer
+		want    string
+		wantErr bool
+	}{
+		{name: "total",
+			footers: []Footer{
+				{Label: "Total", Aggregates: []Aggregate{
+					{Column: 1, Function: AggregateCount},
+					{Column: 2, Function: AggregateSum}}}},
+			want: `│ Name  │ Units │ Price │
+├───────┼───────┼───────┤
+│ tea   │       │   1.5 │
+│ cake  │     2 │    12 │
+├───────┼───────┼───────┤
+│ milk  │     1 │     3 │
+├───────┼───────┼───────┤
+│ Total │     2 │  16.5 │`},
+		{name: "rule",
+			footers: []Footer{
+				{Rule: Double, Aggregates: []Aggregate{
+					{Column: 0, Function: AggregateCount},
+					{Column: 2, Function: AggregateMax}}}},
+			want: `│ Name │ Units │ Price │
+├──────┼───────┼───────┤
+│ tea  │       │   1.5 │
+│ cake │     2 │    12 │
+├──────┼───────┼───────┤
+│ milk │     1 │     3 │
+╞══════╪═══════╪═══════╡
+│ 0    │       │    12 │`},
+		{name: "blocks",
+			footers: []Footer{
+				{Label: "Subtotal", Block: true, Aggregates: []Aggregate{
+					{Column: 2, Function: AggregateMean}}},
+				{Label: "Total", Rule: Thick, Aggregates: []Aggregate{
+					{Column: 2, Function: AggregateMin}}}},
+			want: `│ Name     │ Units │ Price │
+├──────────┼───────┼───────┤
+│ tea      │       │   1.5 │
+│ cake     │     2 │    12 │
+├──────────┼───────┼───────┤
+│ milk     │     1 │     3 │
+├──────────┼───────┼───────┤
+│ Subtotal │       │     3 │
+┝━━━━━━━━━━┿━━━━━━━┿━━━━━━━┥
+│ Total    │       │   1.5 │`},
+		{name: "reduce",
+			footers: []Footer{
+				{Aggregates: []Aggregate{
+					{Column: 2, Reduce: func(values []float64) any {
+						return fmt.Sprintf("%v values", len(values))
+					}}}}},
+			want: `│ Name │ Units │    Price │
+├──────┼───────┼──────────┤
+│ tea  │       │      1.5 │
+│ cake │     2 │       12 │
+├──────┼───────┼──────────┤
+│ milk │     1 │        3 │
+├──────┼───────┼──────────┤
+│      │       │ 3 values │`},
+		{name: "none",
+			footers: []Footer{{Label: "Total"}},
+			wantErr: true},
+		{name: "column",
+			footers: []Footer{{Aggregates: []Aggregate{{Column: 3}}}},
+			wantErr: true},
+		{name: "twice",
+			footers: []Footer{{Aggregates: []Aggregate{{Column: 1}, {Column: 1}}}},
+			wantErr: true},
+		{name: "function",
+			footers: []Footer{{Aggregates: []Aggregate{{Column: 1, Function: Aggregation(7)}}}},
+			wantErr: true},
+		{name: "separator",
+			footers: []Footer{{Rule: Separator(7), Aggregates: []Aggregate{{Column: 1}}}},
+			wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table, _ := NewTable("│ l │ r │ r │")
+			table.AddRow("Name", "Units", "Price")
+			table.AddSingleRule()
+			table.AddRow("tea", "", 1.5)
+			table.AddRow("cake", 2, Cell{Value: 12})
+			table.AddSingleRule()
+			table.AddRow("milk", uint(1), float32(3))
+
+			var err error
+			for _, footer := range tt.footers {
+				if err = table.AddFooter(footer); err != nil {
+					break
+				}
+			}
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("AddFooter() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				if table.GetNbRows() != 6 {
+					t.Errorf("AddFooter() modified the table on error")
+				}
+				return
+			}
+			if got := table.String(); got != tt.want {
+				t.Errorf("String() =\n%v\nwant\n%v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTable_AddFooterUpdate(t *testing.T) {
+
+	table, _ := NewTable("│ l │ r │")
+	table.AddRow("a", 1)
+	table.AddRow("b", 2)
+	table.AddFooter(Footer{Label: "Total", Aggregates: []Aggregate{
+		{Column: 1, Function: AggregateSum}}})
+
+	// aggregates are computed again when the table is modified
+	table.SetCell(0, 1, 40)
+	table.InsertRow(1, "c", 100)
+	want := `│ a     │  40 │
+│ c     │ 100 │
+│ b     │   2 │
+├───────┼─────┤
+│ Total │ 142 │`
+	if got := table.String(); got != want {
+		t.Errorf("String() =\n%v\nwant\n%v", got, want)
+	}
+	table.DeleteRow(1)
+	if info, _ := table.Cell(3, 1); info.Value != 42.0 {
+		t.Errorf("Cell(3, 1).Value = %v, want 42", info.Value)
+	}
+}
+
+func TestTable_AddFooterClosed(t *testing.T) {
+
+	// the last rule of the table is replaced with the rule of the footer and
+	// drawn again after it
+	table, _ := NewTable("│ l │ r │")
+	table.AddThickRule()
+	table.AddRow("a", 1)
+	table.AddRow("b", 2)
+	table.AddThickRule()
+	table.AddFooter(Footer{Label: "Subtotal", Block: true, Aggregates: []Aggregate{
+		{Column: 1, Function: AggregateSum}}})
+	table.AddFooter(Footer{Label: "Total", Rule: Double, Aggregates: []Aggregate{
+		{Column: 1, Function: AggregateSum}}})
+	want := `┍━━━━━━━━━━┯━━━┑
+│ a        │ 1 │
+│ b        │ 2 │
+├──────────┼───┤
+│ Subtotal │ 3 │
+╞══════════╪═══╡
+│ Total    │ 3 │
+┕━━━━━━━━━━┷━━━┙`
+	if got := table.String(); got != want {
+		t.Errorf("String() =\n%v\nwant\n%v", got, want)
+	}
+}
+
+// Local Variables:
+// mode:go
+// fill-column:80
+// End:
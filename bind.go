@@ -0,0 +1,377 @@
+// -*- coding: utf-8 -*-
+// bind.go
+// -----------------------------------------------------------------------------
+//
+// Started on <sáb 17-10-2026 01:29:12 (1792200552)>
+//
+
+package table
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ----------------------------------------------------------------------------
+// Binding
+// ----------------------------------------------------------------------------
+
+// Functions
+// ----------------------------------------------------------------------------
+
+// -- Private
+
+// return true if the given type (or the type it points to) is numeric
+func isNumeric(typ reflect.Type) bool {
+
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	switch typ.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// return the item to show for the given value. Stringers (either with a value
+// or pointer receiver) and errors are shown with the text of their own methods,
+// nil values are shown as empty strings, and pointers are followed. Booleans,
+// numbers and strings are returned as they are, so that tables retain their
+// values, and any other value is shown as it would be shown by AddRow
+func getBoundValue(v reflect.Value) any {
+
+	for {
+
+		// invalid values are those of fields that could not be accessed (e.g.,
+		// because they are embedded in a nil pointer)
+		if !v.IsValid() {
+			return ""
+		}
+		if (v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface) && v.IsNil() {
+			return ""
+		}
+
+		// stringers and errors are shown with their own method
+		if v.CanInterface() {
+			switch value := v.Interface().(type) {
+			case fmt.Stringer:
+				return value.String()
+			case error:
+				return value.Error()
+			}
+			if v.CanAddr() {
+				if value, ok := v.Addr().Interface().(fmt.Stringer); ok {
+					return value.String()
+				}
+			}
+		}
+
+		// follow pointers and interfaces, and show any other value as it is
+		if v.Kind() != reflect.Pointer && v.Kind() != reflect.Interface {
+			break
+		}
+		v = v.Elem()
+	}
+	if !v.CanInterface() {
+		return ""
+	}
+	if v.Kind() == reflect.Bool || v.Kind() == reflect.String || isNumeric(v.Type()) {
+		return v.Interface()
+	}
+	return fmt.Sprintf("%v", v.Interface())
+}
+
+// parse the tag of the given field and update the given binding accordingly.
+// It returns true if the field has to be shown and false if it is hidden. In
+// case the tag could not be processed an error is returned
+func parseTag(field reflect.StructField, b *binding) (bool, error) {
+
+	tag, ok := field.Tag.Lookup(table_tag)
+	if !ok {
+		return true, nil
+	}
+
+	// the first item is the header, and a dash means that the field is hidden
+	items := strings.Split(tag, ",")
+	if items[0] == "-" && len(items) == 1 {
+		return false, nil
+	}
+	if items[0] != "" {
+		b.header = items[0]
+	}
+
+	// the rest are either the specification of the column, its order or the
+	// keyword hidden
+	re := regexp.MustCompile("^" + columnSpecRegex + "$")
+	for _, item := range items[1:] {
+		item = strings.TrimSpace(item)
+		switch {
+		case item == "hidden":
+			return false, nil
+		case strings.HasPrefix(item, "order="):
+			order, err := strconv.Atoi(strings.TrimPrefix(item, "order="))
+			if err != nil || order < 0 {
+				return false, fmt.Errorf("invalid order '%v' in the tag of field %v", item, field.Name)
+			}
+			b.order = order
+		case re.MatchString(item):
+			b.spec = item
+		default:
+			return false, fmt.Errorf("invalid option '%v' in the tag of field %v", item, field.Name)
+		}
+	}
+	return true, nil
+}
+
+// return the bindings of all exported fields of the given struct type, whose
+// index sequence starts with the given prefix. Embedded structs with no tag are
+// flattened, i.e., their fields are bound as if they were fields of the outer
+// struct
+func getStructBindings(typ reflect.Type, prefix []int) (bindings []binding, err error) {
+
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		index := append(append([]int{}, prefix...), i)
+
+		// embedded structs with no tag are flattened
+		ftype := field.Type
+		if ftype.Kind() == reflect.Pointer {
+			ftype = ftype.Elem()
+		}
+		if _, ok := field.Tag.Lookup(table_tag); field.Anonymous && !ok && ftype.Kind() == reflect.Struct {
+			embedded, err := getStructBindings(ftype, index)
+			if err != nil {
+				return nil, err
+			}
+			bindings = append(bindings, embedded...)
+			continue
+		}
+
+		// unexported fields are ignored
+		if !field.IsExported() {
+			continue
+		}
+
+		// by default, numeric fields are ragged left and any other is ragged
+		// right
+		b := binding{index: index, header: field.Name, spec: "l", order: -1}
+		if isNumeric(field.Type) {
+			b.spec = "r"
+		}
+		if show, err := parseTag(field, &b); err != nil {
+			return nil, err
+		} else if show {
+			bindings = append(bindings, b)
+		}
+	}
+	return
+}
+
+// return the bindings of all keys found in the given slice of maps sorted in
+// ascending order. Keys whose values are all numeric are ragged left and the
+// others are ragged right
+func getMapBindings(data reflect.Value) (bindings []binding) {
+
+	// compute the union of all keys and whether their values are all numeric
+	numeric := make(map[string]bool)
+	for i := 0; i < data.Len(); i++ {
+		item := data.Index(i)
+		for item.Kind() == reflect.Pointer || item.Kind() == reflect.Interface {
+			item = item.Elem()
+		}
+		if !item.IsValid() {
+			continue
+		}
+		for _, key := range item.MapKeys() {
+			value := item.MapIndex(key)
+			for value.Kind() == reflect.Interface && !value.IsNil() {
+				value = value.Elem()
+			}
+			isnum, ok := numeric[key.String()]
+			numeric[key.String()] = (isnum || !ok) && isNumeric(value.Type())
+		}
+	}
+
+	// and create a binding for every key
+	for key, isnum := range numeric {
+		b := binding{key: key, header: key, spec: "l", order: -1}
+		if isnum {
+			b.spec = "r"
+		}
+		bindings = append(bindings, b)
+	}
+	sort.Slice(bindings, func(i, j int) bool {
+		return bindings[i].key < bindings[j].key
+	})
+	return
+}
+
+// Methods
+// ----------------------------------------------------------------------------
+
+// -- Private
+
+// return the value bound to the given item
+func (b binding) getValue(item reflect.Value) reflect.Value {
+
+	for item.Kind() == reflect.Pointer || item.Kind() == reflect.Interface {
+		if item.IsNil() {
+			return reflect.Value{}
+		}
+		item = item.Elem()
+	}
+
+	// maps are accessed with the key of the binding, and structs with the index
+	// sequence. Fields embedded in nil pointers are returned as invalid values
+	if item.Kind() == reflect.Map {
+		return item.MapIndex(reflect.ValueOf(b.key).Convert(item.Type().Key()))
+	}
+	value, err := item.FieldByIndexErr(b.index)
+	if err != nil {
+		return reflect.Value{}
+	}
+	return value
+}
+
+// -- Public
+
+// NewTableFromSlice creates a new table from the given slice (or array) of
+// either structs or maps with string keys, where every item is shown in a
+// different row. Pointers to structs and maps are also accepted, and nil items
+// are shown as empty rows.
+//
+// The first row of the table shows the header of every column, and it is
+// followed by a single horizontal rule. All columns are separated with the
+// given separator, which is also used before the first column and after the
+// last one unless it is empty. It accepts the same separators than NewTable.
+//
+// In case of structs, every exported field is shown in a different column in
+// the same order they are declared, and fields of embedded structs are shown as
+// if they belonged to the outer struct. By default, the header of every column
+// is the name of the field, and numeric fields are ragged left whereas all the
+// others are ragged right. These defaults can be modified with the struct tag
+// "table" whose value is a comma-separated list: the first item is the header
+// of the column, and the rest of options can be:
+//
+// 1. Any specification of a column accepted by NewTable, e.g., "c" or "p{20}"
+//
+// 2. "order=N": fields with an explicit order are shown first in ascending
+// order, and the rest are shown afterwards
+//
+// 3. "hidden": the field is not shown. This is equivalent to use "-" as the
+// value of the tag.
+//
+// For example:
+//
+//	type Item struct {
+//		Name        string  `table:"Product,c,order=0"`
+//		Description string  `table:",p{30}"`
+//		Price       float64 `table:"Price (€)"`
+//		id          int
+//		Internal    string  `table:"-"`
+//	}
+//
+// In case of maps, every key is shown in a different column in ascending
+// order, with the key as header. Keys whose values are all numeric are ragged
+// left and the rest are ragged right.
+//
+// Values are shown with their String (or Error) method if they implement it
+// and otherwise, as they would be shown by AddRow. Booleans, numbers and
+// strings are given to the table as they are, so that they can be aggregated,
+// sorted and formatted as the values given to AddRow.
+//
+// Empty slices of structs are shown with the header only.
+//
+// NewTableFromSlice returns an error if the given data is neither a slice nor
+// an array of structs or maps, any of the tags could not be processed, or it is
+// an empty slice of maps, whose columns can not be known.
+func NewTableFromSlice(data any, sep string) (*Table, error) {
+
+	// first things first, verify the type of the given data
+	value := reflect.ValueOf(data)
+	for value.Kind() == reflect.Pointer && !value.IsNil() {
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Slice && value.Kind() != reflect.Array {
+		return &Table{}, errors.New("NewTableFromSlice accepts only slices or arrays")
+	}
+	typ := value.Type().Elem()
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+
+	// and compute the bindings of every column
+	var bindings []binding
+	var err error
+	switch {
+	case typ.Kind() == reflect.Struct:
+		if bindings, err = getStructBindings(typ, nil); err != nil {
+			return &Table{}, err
+		}
+	case typ.Kind() == reflect.Map && typ.Key().Kind() == reflect.String:
+		bindings = getMapBindings(value)
+	default:
+		return &Table{}, fmt.Errorf("NewTableFromSlice can not bind items of type %v", typ)
+	}
+
+	// empty slices of maps are rejected, as their columns are known only from
+	// their items
+	if typ.Kind() == reflect.Map && value.Len() == 0 {
+		return &Table{}, errors.New("There are no items to show")
+	}
+	if len(bindings) == 0 {
+		return &Table{}, errors.New("There are no columns to show")
+	}
+
+	// columns with an explicit order are shown first
+	sort.SliceStable(bindings, func(i, j int) bool {
+		return bindings[i].order >= 0 &&
+			(bindings[j].order < 0 || bindings[i].order < bindings[j].order)
+	})
+
+	// now, create the table with the specification of all columns
+	var specs []string
+	var headers []any
+	for _, b := range bindings {
+		specs = append(specs, b.spec)
+		headers = append(headers, b.header)
+	}
+	colspec := strings.Join(specs, "  ")
+	if sep != "" {
+		colspec = sep + " " + strings.Join(specs, " "+sep+" ") + " " + sep
+	}
+	t, err := NewTable(colspec)
+	if err != nil {
+		return &Table{}, err
+	}
+
+	// and add the header followed by a single rule and then all items
+	if err := t.AddRow(headers...); err != nil {
+		return &Table{}, err
+	}
+	t.AddSingleRule()
+	for i := 0; i < value.Len(); i++ {
+		var cells []any
+		for _, b := range bindings {
+			cells = append(cells, getBoundValue(b.getValue(value.Index(i))))
+		}
+		if err := t.AddRow(cells...); err != nil {
+			return &Table{}, err
+		}
+	}
+
+	return t, nil
+}
+
+// Local Variables:
+// mode:go
+// fill-column:80
+// End:
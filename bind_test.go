@@ -0,0 +1,161 @@
+// -*- coding: utf-8 -*-
+// bind_test.go
+// -----------------------------------------------------------------------------
+//
+// Started on <sáb 17-10-2026 01:29:12 (1792200552)>
+//
+
+package table
+
+import (
+	"fmt"
+	"testing"
+)
+
+type bindPrice float64
+
+func (p *bindPrice) String() string {
+	return fmt.Sprintf("%.2f €", float64(*p))
+}
+
+type bindAudit struct {
+	Owner string
+}
+
+type bindItem struct {
+	bindAudit
+	Name     string    `table:"Product,c,order=0"`
+	Units    int       `table:",order=1"`
+	Price    bindPrice `table:"Price,r"`
+	Notes    string    `table:",p{6}"`
+	Internal string    `table:"-"`
+	Secret   string    `table:"Secret,hidden"`
+	id       int
+}
+
+func TestNewTableFromSlice(t *testing.T) {
+	tests := []struct {
+		name string
+		data any
+		sep  string
+		want string
+	}{
+		{name: "structs",
+			data: []bindItem{
+				{bindAudit{"ann"}, "screw", 100, 0.5, "metric thread", "x", "y", 1},
+				{bindAudit{"bob"}, "nut", 7, 12, "", "x", "y", 2},
+			},
+			sep: "|",
+			want: `│ Product │ Units │ Owner │   Price │ Notes  │
+├─────────┼───────┼───────┼─────────┼────────┤
+│  screw  │   100 │ ann   │  0.50 € │ metric │
+│         │       │       │         │ thread │
+│   nut   │     7 │ bob   │ 12.00 € │        │`},
+		{name: "pointers",
+			data: []*bindAudit{{"ann"}, nil},
+			sep:  "",
+			want: `Owner
+─────
+ann  
+     `},
+		{name: "maps",
+			data: []map[string]any{
+				{"name": "screw", "units": 100},
+				{"name": "nut", "units": 7, "notes": nil},
+			},
+			sep: "|",
+			want: `│ name  │ notes │ units │
+├───────┼───────┼───────┤
+│ screw │       │   100 │
+│ nut   │       │     7 │`},
+
+		// empty slices of structs are shown with the header only
+		{name: "empty",
+			data: []struct {
+				Name  string
+				Units int
+			}{},
+			sep: "|",
+			want: `│ Name │ Units │
+└──────┴───────┘`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tab, err := NewTableFromSlice(tt.data, tt.sep)
+			if err != nil {
+				t.Fatalf("NewTableFromSlice() = %v", err)
+			}
+			if got := tab.String(); got != tt.want {
+				t.Errorf("NewTableFromSlice() =\n%v\nwant\n%v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewTableFromSlice_Values(t *testing.T) {
+
+	type order struct {
+		Name  string
+		Qty   int
+		Price float64
+	}
+	tab, err := NewTableFromSlice([]order{
+		{"tea", 3, 1.5},
+		{"coffee", 12, 2},
+		{"cake", 1, 3.25}}, "|")
+	if err != nil {
+		t.Fatalf("NewTableFromSlice() = %v", err)
+	}
+
+	// values retain their types, so that they are sorted as numbers and they
+	// can be aggregated
+	if info, _ := tab.Cell(2, 1); info.Value != 3 {
+		t.Errorf("Cell(2, 1).Value = %#v, want 3", info.Value)
+	}
+	if err := tab.SortBy(SortKey{Column: 1}); err != nil {
+		t.Fatalf("SortBy() = %v", err)
+	}
+	if err := tab.AddFooter(Footer{Label: "Total", Aggregates: []Aggregate{
+		{Column: 1, Function: AggregateSum},
+		{Column: 2, Function: AggregateSum}}}); err != nil {
+		t.Fatalf("AddFooter() = %v", err)
+	}
+	want := `│ Name   │ Qty │ Price │
+├────────┼─────┼───────┤
+│ cake   │   1 │  3.25 │
+│ tea    │   3 │   1.5 │
+│ coffee │  12 │     2 │
+├────────┼─────┼───────┤
+│ Total  │  16 │  6.75 │`
+	if got := tab.String(); got != want {
+		t.Errorf("NewTableFromSlice() =\n%v\nwant\n%v", got, want)
+	}
+}
+
+func TestNewTableFromSlice_Errors(t *testing.T) {
+
+	type badOrder struct {
+		Name string `table:",order=x"`
+	}
+	type badSpec struct {
+		Name string `table:",q"`
+	}
+	tests := []struct {
+		name string
+		data any
+	}{
+		{name: "not a slice", data: 3},
+		{name: "slice of ints", data: []int{1, 2}},
+		{name: "no columns", data: []struct{ id int }{{1}}},
+		{name: "no maps", data: []map[string]any{}},
+		{name: "invalid order", data: []badOrder{}},
+		{name: "invalid spec", data: []badSpec{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewTableFromSlice(tt.data, "|"); err == nil {
+				t.Errorf("NewTableFromSlice() did not return an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,136 @@
+// -*- coding: utf-8 -*-
+// cell.go
+// -----------------------------------------------------------------------------
+//
+// Started on <sáb 17-10-2026 01:39:32 (1792201172)>
+//
+
+package table
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// ----------------------------------------------------------------------------
+// Cell
+// ----------------------------------------------------------------------------
+
+// Functions
+// ----------------------------------------------------------------------------
+
+// return a new cell with the contents and format given by the user. In case
+// the format is not correct an error is returned
+func newCell(c Cell) (cell, error) {
+
+	if c.HAlign != 0 && c.HAlign != 'l' && c.HAlign != 'c' && c.HAlign != 'r' {
+		return cell{}, fmt.Errorf("'%c' is an incorrect horizontal format", c.HAlign)
+	}
+	if c.VAlign != 0 && c.VAlign != 't' && c.VAlign != 'c' && c.VAlign != 'b' {
+		return cell{}, fmt.Errorf("'%c' is an incorrect vertical format", c.VAlign)
+	}
+	if c.PadLeft < 0 || c.PadRight < 0 || c.Truncate < 0 {
+		return cell{}, fmt.Errorf("Neither the padding nor the truncation of a cell can be negative")
+	}
+
+	return cell{
+		text:     content(fmt.Sprintf("%v", c.Value)),
+		data:     c.Value,
+		halign:   c.HAlign,
+		valign:   c.VAlign,
+		ansi:     c.Style,
+		padleft:  c.PadLeft,
+		padright: c.PadRight,
+		truncate: c.Truncate,
+	}, nil
+}
+
+// Methods
+// ----------------------------------------------------------------------------
+
+// -- Private
+
+// return the specification of the jcol-th column of the table as it is used to
+// show this cell, i.e., with its format overriding that of the column. The width
+// of the column excludes the padding of the cell
+func (c cell) getColumn(t *Table, jcol int) column {
+
+	col := t.columns[jcol]
+	padding := c.padleft + c.padright
+
+	// the horizontal alignment of paragraphs and columns whose contents are
+	// truncated is modified preserving their width
+	if c.halign != 0 {
+		switch {
+		case col.hformat.isParagraph():
+			col.hformat.alignment = byte(unicode.ToUpper(rune(c.halign)))
+		case col.hformat.isTruncated():
+			col.hformat.alignment = c.halign
+		default:
+			col.hformat = style{alignment: c.halign}
+		}
+	}
+	if c.valign != 0 {
+		col.vformat = style{alignment: c.valign}
+	}
+
+	// in case the contents have to be truncated, then they can not take more
+	// space than that available in paragraphs
+	if c.truncate > 0 {
+		width := c.truncate
+		if col.hformat.isParagraph() || col.hformat.isTruncated() {
+			width = min(width, col.hformat.arg-padding)
+		}
+		alignment := byte(unicode.ToLower(rune(col.hformat.alignment)))
+		switch alignment {
+		case 'c', 'r':
+		case 'd':
+			alignment = 'r'
+		default:
+			alignment = 'l'
+		}
+		col.hformat = style{alignment: alignment, arg: max[int](1, width)}
+	} else if col.hformat.isParagraph() || col.hformat.isTruncated() {
+		col.hformat.arg = max[int](1, col.hformat.arg-padding)
+	}
+
+	col.width = max[int](0, col.width-padding)
+	return col
+}
+
+// -- Public
+
+// Processing a cell means processing its contents as if they were shown in a
+// column with the format of the cell. Each physical line is returned as a cell
+// with the same format
+func (c cell) Process(t *Table, irow, jcol int) []formatter {
+
+	var output []formatter
+	for _, line := range c.text.process(t, irow, jcol, c.getColumn(t, jcol)) {
+		c.text = line
+		output = append(output, formatter(c))
+	}
+	return output
+}
+
+// Cells are formatted as contents with the format of the cell, surrounded by
+// their padding and with their own ANSI style
+func (c cell) Format(t *Table, irow, jcol int) string {
+
+	// get the separator to use
+	sep := t.columns[jcol].sep
+
+	text := strings.Repeat(string(horizontal_blank), c.padleft) +
+		c.text.justify(c.getColumn(t, jcol)) +
+		strings.Repeat(string(horizontal_blank), c.padright)
+	if c.ansi != "" {
+		text = c.ansi + text + ansiReset
+	}
+	return sep + text
+}
+
+// Local Variables:
+// mode:go
+// fill-column:80
+// End:
@@ -0,0 +1,93 @@
+// -*- coding: utf-8 -*-
+// cell_test.go
+// -----------------------------------------------------------------------------
+//
+// Started on <sáb 17-10-2026 01:39:32 (1792201172)>
+//
+
+package table
+
+import "testing"
+
+func TestCell(t *testing.T) {
+	tests := []struct {
+		name  string
+		table func() *Table
+		want  string
+	}{
+		{name: "alignment",
+			table: func() *Table {
+				t, _ := NewTable("| l | r | p{8} |")
+				t.AddRow("Name", Cell{Value: "Value", HAlign: 'c'}, Cell{Value: "Notes", HAlign: 'c'})
+				t.AddSingleRule()
+				t.AddRow(Cell{Value: "Earth", HAlign: 'r'}, 1.5, Cell{Value: "a long note here", HAlign: 'r'})
+				t.AddRow(Cell{Value: "Mars", VAlign: 'b'}, Cell{Value: 2, VAlign: 'c'}, "two\nlines\nhere")
+				return t
+			},
+			want: `│ Name  │ Value │  Notes   │
+├───────┼───────┼──────────┤
+│ Earth │   1.5 │   a long │
+│       │       │     note │
+│       │       │     here │
+│       │       │ two      │
+│       │     2 │ lines    │
+│ Mars  │       │ here     │`},
+
+		{name: "padding",
+			table: func() *Table {
+				t, _ := NewTable("| l | p{6} |")
+				t.AddRow(Cell{Value: "Name", PadLeft: 2, PadRight: 1}, Cell{Value: "abc def", PadLeft: 2})
+				t.AddRow("Earth", "abc def")
+				return t
+			},
+			want: `│   Name  │   abc  │
+│         │   def  │
+│ Earth   │ abc    │
+│         │ def    │`},
+
+		{name: "truncation",
+			table: func() *Table {
+				t, _ := NewTable("| l | p{6} |")
+				t.AddRow(Cell{Value: "a very long name", Truncate: 6}, Cell{Value: "abc def ghi", Truncate: 10})
+				t.AddRow("Earth", "abc")
+				return t
+			},
+			want: `│ a ver… │ abc d… │
+│ Earth  │ abc    │`},
+
+		{name: "style",
+			table: func() *Table {
+				t, _ := NewTable("| l | l |")
+				t.AddRow(Cell{Value: "bold", Style: "\033[1m"}, "plain")
+				return t
+			},
+			want: "│ \033[1mbold\033[0m │ plain │"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.table().String(); got != tt.want {
+				t.Errorf("Table.String() =\n%v\nwant\n%v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCell_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		cell Cell
+	}{
+		{name: "horizontal alignment", cell: Cell{HAlign: 'p'}},
+		{name: "vertical alignment", cell: Cell{VAlign: 'x'}},
+		{name: "padding", cell: Cell{PadLeft: -1}},
+		{name: "truncation", cell: Cell{Truncate: -1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tab, _ := NewTable("l")
+			if err := tab.AddRow(tt.cell); err == nil {
+				t.Errorf("Table.AddRow() did not return an error")
+			}
+		})
+	}
+}
@@ -24,6 +24,23 @@ import (
 // cell
 func (c content) Process(t *Table, irow, jcol int) []formatter {
 
+	// syntactical issue: explicitly transform the slice of contents into a
+	// slice of formatters. Note this transformation guarantees casting back the
+	// formatters into contents so that they can be properly formatted each
+	var output []formatter
+	for _, val := range c.process(t, irow, jcol, t.columns[jcol]) {
+		output = append(output, formatter(val))
+	}
+
+	// and return them
+	return output
+}
+
+// process this content as if it were shown in the jcol-th column of the table
+// with the given specification, and return the physical lines necessary to
+// show it. This allows cells to override the specification of their column
+func (c content) process(t *Table, irow, jcol int, col column) []content {
+
 	// Processing a content involves splitting it across as many physical rows
 	// as needed (e.g., if a "paragraph" alignment is given for this column). In
 	// case the number of physical lines is strictly less than the number of
@@ -43,9 +60,6 @@ func (c content) Process(t *Table, irow, jcol int) []formatter {
 		}
 	} else {
 
-		// aliasing
-		col := t.columns[jcol]
-
 		// get the number of physical rows of this logical row taking into account
 		// that this logical row might not have been added to the table yet
 		var nbrows int
@@ -57,11 +71,13 @@ func (c content) Process(t *Table, irow, jcol int) []formatter {
 
 		// if a paragraph alignment (p, C, L, R) modifier is used for this specific
 		// column, then split the content
-		if col.hformat.alignment == 'p' ||
-			col.hformat.alignment == 'C' ||
-			col.hformat.alignment == 'L' ||
-			col.hformat.alignment == 'R' {
+		if col.hformat.isParagraph() {
 			result = strToContent(splitParagraph(string(c), col.hformat.arg))
+		} else if col.hformat.isTruncated() {
+
+			// if the contents have to be truncated, then they are shown in
+			// one single line
+			result = []content{content(truncateString(string(c), col.hformat.arg, t.getEllipsis()))}
 		} else {
 
 			// if, on the other hand, a newline character has been provided, split the
@@ -111,16 +127,7 @@ func (c content) Process(t *Table, irow, jcol int) []formatter {
 		}
 	}
 
-	// syntactical issue: explicitly transform the slice of contents into a
-	// slice of formatters. Note this transformation guarantees casting back the
-	// formatters into contents so that they can be properly formatted each
-	var output []formatter
-	for _, val := range result {
-		output = append(output, formatter(val))
-	}
-
-	// and return them
-	return output
+	return result
 }
 
 // Cells are also formatted (physical) line by line where each physical line is
@@ -134,18 +141,27 @@ func (c content) Format(t *Table, irow, jcol int) string {
 	// horizontal format and to prefix the result with the separator of the
 	// jcol-th column
 
-	// aliasing
-	col := t.columns[jcol]
-
-	// in case it is necessary, the prefix and suffix contain a string of blank
-	// characters to insert properly so that the contents satisfy the format of
-	// this column
-	prefix, suffix := justifyLine(string(c), rune(col.hformat.alignment), col.width)
-
 	// get the separator to use
 	sep := t.columns[jcol].sep
 
 	// and return the concatenation of the prefix, the content and the suffix,
 	// all prefixed with the horizontal separator of the jcol-th column
-	return fmt.Sprintf("%v%v", sep, prefix+string(c)+suffix)
+	return fmt.Sprintf("%v%v", sep, c.justify(t.columns[jcol]))
+}
+
+// return this content justified according to the horizontal format of the
+// given column, i.e., preceded and followed by the blank characters required
+// to satisfy its format
+func (c content) justify(col column) string {
+
+	// in case it is necessary, the prefix and suffix contain a string of blank
+	// characters to insert properly so that the contents satisfy the format of
+	// this column
+	var prefix, suffix string
+	if col.hformat.isDecimal() {
+		prefix, suffix = justifyDecimal(string(c), col.before, col.after, col.width)
+	} else {
+		prefix, suffix = justifyLine(string(c), rune(col.hformat.alignment), col.width)
+	}
+	return prefix + string(c) + suffix
 }
@@ -0,0 +1,130 @@
+// -*- coding: utf-8 -*-
+// csv.go
+// -----------------------------------------------------------------------------
+//
+// Started on <sáb 17-10-2026 01:27:54 (1792200474)>
+//
+
+package table
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ----------------------------------------------------------------------------
+// CSV
+// ----------------------------------------------------------------------------
+
+// Methods
+// ----------------------------------------------------------------------------
+
+// -- Private
+
+// return the text of the given location which is known to be taken by the
+// given multicell according to the specified span policy
+func (t *Table) getSpanText(m *multicell, irow, jcol int, policy SpanPolicy) string {
+
+	switch policy {
+	case SpanRepeat:
+		return getCellText(*m)
+
+	case SpanFlatten:
+
+		// compute the index of this location within the cells spanned by the
+		// multicell, counting only data rows
+		idx := t.getNbDataRows(m.getRowInit(), irow-m.getRowInit())*m.getNbColumns() +
+			jcol - m.getColumnInit()
+		if idx >= len(m.args) {
+			return ""
+		}
+
+		// in case this is the last location spanned by the multicell, then
+		// add all the remaining arguments
+		last := idx + 1
+		if idx == t.getNbDataRows(m.getRowInit(), m.getNbRows())*m.getNbColumns()-1 {
+			last = len(m.args)
+		}
+		var args []string
+		for _, arg := range m.args[idx:last] {
+			args = append(args, stripANSIColors(fmt.Sprintf("%v", arg)))
+		}
+		return strings.Join(args, " ")
+	}
+
+	// by default, the text is shown only in the first location
+	if m.getRowInit() == irow && m.getColumnInit() == jcol {
+		return getCellText(*m)
+	}
+	return ""
+}
+
+// return the records of this table, i.e., the text of every cell of all data
+// rows, where the cells spanned by multicells are given according to the
+// specified policy
+func (t *Table) getRecords(policy SpanPolicy) (records [][]string) {
+
+	for irow := 0; irow < len(t.cells); irow++ {
+
+		// horizontal rules are skipped
+		if t.isRule(irow) {
+			continue
+		}
+
+		var record []string
+		for j := 0; j < t.GetNbColumns(); j++ {
+			if m := t.getMulticell(irow, j); m != nil {
+				record = append(record, t.getSpanText(m, irow, j, policy))
+			} else {
+				record = append(record, getCellText(t.cells[irow][j]))
+			}
+		}
+		records = append(records, record)
+	}
+
+	return
+}
+
+// write the records of this table to the given writer using the specified
+// field delimiter
+func (t *Table) writeRecords(w io.Writer, comma rune, policy SpanPolicy) error {
+
+	writer := csv.NewWriter(w)
+	writer.Comma = comma
+	if err := writer.WriteAll(t.getRecords(policy)); err != nil {
+		return err
+	}
+	return writer.Error()
+}
+
+// -- Public
+
+// WriteCSV writes all data rows of the table to the given writer in CSV format.
+// Horizontal rules are skipped and the contents of every cell are written as
+// they were given, with no ANSI color codes. Fields are quoted if necessary
+// (e.g., if they contain newlines), and the contents of the cells spanned by
+// multicells are given according to the specified policy.
+//
+// In case writing is not possible, an error is returned
+func (t *Table) WriteCSV(w io.Writer, policy SpanPolicy) error {
+	if t.hasHiddenColumns() {
+		return t.getVisibleTable().WriteCSV(w, policy)
+	}
+	return t.writeRecords(w, ',', policy)
+}
+
+// WriteTSV writes all data rows of the table to the given writer in TSV format,
+// i.e., fields are separated by tabs. Otherwise, it behaves as WriteCSV
+func (t *Table) WriteTSV(w io.Writer, policy SpanPolicy) error {
+	if t.hasHiddenColumns() {
+		return t.getVisibleTable().WriteTSV(w, policy)
+	}
+	return t.writeRecords(w, '\t', policy)
+}
+
+// Local Variables:
+// mode:go
+// fill-column:80
+// End:
@@ -0,0 +1,84 @@
+// -*- coding: utf-8 -*-
+// csv_test.go
+// -----------------------------------------------------------------------------
+//
+// Started on <sáb 17-10-2026 01:27:54 (1792200474)>
+//
+
+package table
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTable_WriteCSV(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy SpanPolicy
+		want   string
+	}{
+		{name: "first",
+			policy: SpanFirst,
+			want: `Name,Value,Unit
+"multi
+line",1,
+A B,,km
+M,2,m
+,3,m
+`},
+		{name: "repeat",
+			policy: SpanRepeat,
+			want: `Name,Value,Unit
+"multi
+line",1,
+A B,A B,km
+M,2,m
+M,3,m
+`},
+		{name: "flatten",
+			policy: SpanFlatten,
+			want: `Name,Value,Unit
+"multi
+line",1,
+A,B,km
+M,2,m
+,3,m
+`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tab, _ := NewTable("| l | r | c |")
+			tab.AddThickRule()
+			tab.AddRow("Name", "\033[1mValue\033[0m", "Unit")
+			tab.AddSingleRule()
+			tab.AddRow("multi\nline", 1)
+			tab.AddRow(Multicell(2, 1, "|cc", "t", "A", "B"), "km")
+			tab.AddRow(Multirow(2, "c", "M"), 2, "m")
+			tab.AddRow(3, "m")
+			tab.AddThickRule()
+
+			var sb strings.Builder
+			if err := tab.WriteCSV(&sb, tt.policy); err != nil {
+				t.Fatalf("Table.WriteCSV() = %v", err)
+			}
+			if got := sb.String(); got != tt.want {
+				t.Errorf("Table.WriteCSV() =\n%v\nwant\n%v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTable_WriteTSV(t *testing.T) {
+
+	tab, _ := NewTable("l l")
+	tab.AddRow("a,b", "c\td")
+
+	var sb strings.Builder
+	if err := tab.WriteTSV(&sb, SpanFirst); err != nil {
+		t.Fatalf("Table.WriteTSV() = %v", err)
+	}
+	if got, want := sb.String(), "a,b\t\"c\td\"\n"; got != want {
+		t.Errorf("Table.WriteTSV() = %q, want %q", got, want)
+	}
+}
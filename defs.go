@@ -8,6 +8,11 @@
 
 package table
 
+import (
+	"io"
+	"unicode"
+)
+
 // ----------------------------------------------------------------------------
 // Constants
 // ----------------------------------------------------------------------------
@@ -24,25 +29,40 @@ const vertical_single = '\u2502' // │
 const vertical_double = '\u2551' // ║
 const vertical_thick = '\u2503'  // ┃
 
-// Regexps
+// UTF-8 box drawing characters
+const box_drawing_first = '\u2500'
+const box_drawing_last = '\u257f'
 
-// the following regexp is used to mach an entire column specification string
-const colSpecRegexAll = `^([^clrCLRp]*(c|l|r|C\{\d+\}|L\{\d+\}|R\{\d+\}|p\{\d+\}))+`
+// Specifiers
 
-// and the following regexp is used to match the specification of a single
+// the following are all the specifiers that can be used in column and row
+// specifications. They are used to report errors
+var colSpecifiers = []string{"l", "c", "r", "d", "L{N}", "C{N}", "R{N}", "p{N}", "l{N}", "c{N}", "r{N}"}
+var rowSpecifiers = []string{"t", "c", "b"}
+
+// columns aligned on the decimal point can not be next to other letters
+const decimalSpecifier = "a decimal column 'd' which is not next to other letters"
+
+// Regexps
+
+// the following regexp is used to match the specification of a single
 // column
-const colSpecRegex = `^[^clrCLRp]*(c|l|r|C\{\d+\}|L\{\d+\}|R\{\d+\}|p\{\d+\})`
+const colSpecRegex = `^[^clrdCLRp]*(c\{\d+\}|l\{\d+\}|r\{\d+\}|c|l|r|d|C\{\d+\}|L\{\d+\}|R\{\d+\}|p\{\d+\})`
 
 // and the following regexp is used to match the specification of a single
 // row
 const rowSpecRegex = `^[^cbt]*(c|b|t)`
 
 // to extract the format of a single column the following regexp is used
-const columnSpecRegex = `(c|l|r|C\{\d+\}|L\{\d+\}|R\{\d+\}|p\{\d+\})`
+const columnSpecRegex = `(c\{\d+\}|l\{\d+\}|r\{\d+\}|c|l|r|d|C\{\d+\}|L\{\d+\}|R\{\d+\}|p\{\d+\})`
 
-// in case a paragraph style is used, the following regexp serves to extract the
-// numerical argument
-const pRegex = `^(C\{\d+\}|L\{\d+\}|R\{\d+\}|p\{\d+\})$`
+// in case a paragraph or truncated style is used, the following regexp serves
+// to extract the numerical argument
+const pRegex = `^(c\{\d+\}|l\{\d+\}|r\{\d+\}|C\{\d+\}|L\{\d+\}|R\{\d+\}|p\{\d+\})$`
+
+// the following regexp is used to extract the offending token of column
+// specifiers that could not be processed, e.g., "p" or "L{}"
+const badSpecRegex = `^[clrdCLRp](\{[^}]*\}?)?`
 
 // to split strings using the newline as a separator
 const newlineRegex = `\n`
@@ -50,6 +70,216 @@ const newlineRegex = `\n`
 // the following regexp is used to start and end ANSI color escape sequences
 const ansiColorRegex = `\033([\[;]\d+)+m`
 
+// the following regexp is used to recognize numbers in columns where they are
+// aligned on the decimal point. Numbers can be signed, use commas as thousands
+// separators and have an exponent
+const decimalRegex = `^[-+]?((\d{1,3}(,\d{3})+|\d+)(\.\d*)?|\.\d+)([eE][-+]?\d+)?$`
+
+// ANSI color escape sequence used to reset all attributes
+const ansiReset = "\033[0m"
+
+// Runes with a special meaning when computing the display width of a string
+const zero_width_joiner = '\u200d'    // ZWJ
+const variation_selector15 = '\ufe0e' // text presentation
+const variation_selector16 = '\ufe0f' // emoji presentation
+const emoji_modifier_first = '\U0001f3fb'
+const emoji_modifier_last = '\U0001f3ff'
+
+// Ellipsis used by default to show that the contents of a cell have been
+// truncated
+const default_ellipsis = "…"
+
+// Minimum width of those columns which are shrunk to fit a table in a maximum
+// width. Note that it is large enough to show East Asian wide characters
+const min_column_width = 2
+
+// Name of the struct tag used to bind fields of structs to columns of a table
+const table_tag = "table"
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+// CSS colors of the standard (0-7) and bright (8-15) colors of ANSI terminals
+var ansiBasicColors = []string{
+	"black", "maroon", "green", "olive", "navy", "purple", "teal", "silver",
+	"gray", "red", "lime", "yellow", "blue", "fuchsia", "aqua", "white",
+}
+
+// Runes that take two cells in a terminal. These are the runes with an East
+// Asian Width property equal to either Wide (W) or Fullwidth (F), along with
+// those emojis that are displayed by default with an emoji presentation
+var eastAsianWide = &unicode.RangeTable{
+	R16: []unicode.Range16{
+		{0x1100, 0x115f, 1}, // Hangul Jamo initial consonants
+		{0x231a, 0x231b, 1},
+		{0x2329, 0x232a, 1},
+		{0x23e9, 0x23ec, 1},
+		{0x23f0, 0x23f0, 1},
+		{0x23f3, 0x23f3, 1},
+		{0x25fd, 0x25fe, 1},
+		{0x2614, 0x2615, 1},
+		{0x2648, 0x2653, 1},
+		{0x267f, 0x267f, 1},
+		{0x2693, 0x2693, 1},
+		{0x26a1, 0x26a1, 1},
+		{0x26aa, 0x26ab, 1},
+		{0x26bd, 0x26be, 1},
+		{0x26c4, 0x26c5, 1},
+		{0x26ce, 0x26ce, 1},
+		{0x26d4, 0x26d4, 1},
+		{0x26ea, 0x26ea, 1},
+		{0x26f2, 0x26f3, 1},
+		{0x26f5, 0x26f5, 1},
+		{0x26fa, 0x26fa, 1},
+		{0x26fd, 0x26fd, 1},
+		{0x2705, 0x2705, 1},
+		{0x270a, 0x270b, 1},
+		{0x2728, 0x2728, 1},
+		{0x274c, 0x274c, 1},
+		{0x274e, 0x274e, 1},
+		{0x2753, 0x2755, 1},
+		{0x2757, 0x2757, 1},
+		{0x2795, 0x2797, 1},
+		{0x27b0, 0x27b0, 1},
+		{0x27bf, 0x27bf, 1},
+		{0x2b1b, 0x2b1c, 1},
+		{0x2b50, 0x2b50, 1},
+		{0x2b55, 0x2b55, 1},
+		{0x2e80, 0x303e, 1}, // CJK radicals, punctuation and ideographic space
+		{0x3041, 0x33ff, 1}, // Hiragana, Katakana, Bopomofo, ...
+		{0x3400, 0x4dbf, 1}, // CJK unified ideographs extension A
+		{0x4e00, 0x9fff, 1}, // CJK unified ideographs
+		{0xa000, 0xa4cf, 1}, // Yi
+		{0xa960, 0xa97f, 1},
+		{0xac00, 0xd7a3, 1}, // Hangul syllables
+		{0xf900, 0xfaff, 1}, // CJK compatibility ideographs
+		{0xfe10, 0xfe19, 1}, // vertical forms
+		{0xfe30, 0xfe6f, 1}, // CJK compatibility forms and small forms
+		{0xff00, 0xff60, 1}, // fullwidth forms
+		{0xffe0, 0xffe6, 1},
+	},
+	R32: []unicode.Range32{
+		{0x16fe0, 0x16fe4, 1},
+		{0x17000, 0x18aff, 1}, // Tangut
+		{0x1b000, 0x1b2ff, 1}, // Kana supplement and extensions
+		{0x1f004, 0x1f004, 1},
+		{0x1f0cf, 0x1f0cf, 1},
+		{0x1f18e, 0x1f18e, 1},
+		{0x1f191, 0x1f19a, 1},
+		{0x1f200, 0x1f202, 1},
+		{0x1f210, 0x1f23b, 1},
+		{0x1f240, 0x1f248, 1},
+		{0x1f250, 0x1f251, 1},
+		{0x1f260, 0x1f265, 1},
+		{0x1f300, 0x1f320, 1}, // emojis ...
+		{0x1f32d, 0x1f335, 1},
+		{0x1f337, 0x1f37c, 1},
+		{0x1f37e, 0x1f393, 1},
+		{0x1f3a0, 0x1f3ca, 1},
+		{0x1f3cf, 0x1f3d3, 1},
+		{0x1f3e0, 0x1f3f0, 1},
+		{0x1f3f4, 0x1f3f4, 1},
+		{0x1f3f8, 0x1f43e, 1},
+		{0x1f440, 0x1f440, 1},
+		{0x1f442, 0x1f4fc, 1},
+		{0x1f4ff, 0x1f53d, 1},
+		{0x1f54b, 0x1f54e, 1},
+		{0x1f550, 0x1f567, 1},
+		{0x1f57a, 0x1f57a, 1},
+		{0x1f595, 0x1f596, 1},
+		{0x1f5a4, 0x1f5a4, 1},
+		{0x1f5fb, 0x1f64f, 1},
+		{0x1f680, 0x1f6c5, 1},
+		{0x1f6cc, 0x1f6cc, 1},
+		{0x1f6d0, 0x1f6d2, 1},
+		{0x1f6d5, 0x1f6d7, 1},
+		{0x1f6dc, 0x1f6df, 1},
+		{0x1f6eb, 0x1f6ec, 1},
+		{0x1f6f4, 0x1f6fc, 1},
+		{0x1f7e0, 0x1f7eb, 1},
+		{0x1f7f0, 0x1f7f0, 1},
+		{0x1f90c, 0x1f93a, 1},
+		{0x1f93c, 0x1f945, 1},
+		{0x1f947, 0x1f9ff, 1},
+		{0x1fa70, 0x1faff, 1}, // ... up to here
+		{0x20000, 0x2fffd, 1}, // CJK unified ideographs extensions B-F
+		{0x30000, 0x3fffd, 1}, // CJK unified ideographs extension G
+	},
+}
+
+// Built-in themes. ThemeUTF8 is the default theme of all tables, and it draws
+// rules and separators with the UTF-8 box drawing characters
+var ThemeUTF8 = Theme{
+	HorizontalSingle: horizontal_single,
+	HorizontalDouble: horizontal_double,
+	HorizontalThick:  horizontal_thick,
+	VerticalSingle:   vertical_single,
+	VerticalDouble:   vertical_double,
+	VerticalThick:    vertical_thick,
+}
+
+// ThemeASCII draws all rules and separators with ASCII characters only, so that
+// tables can be shown in terminals which do not support UTF-8
+var ThemeASCII = Theme{
+	HorizontalSingle: '-',
+	HorizontalDouble: '=',
+	HorizontalThick:  '=',
+	VerticalSingle:   '|',
+	VerticalDouble:   '|',
+	VerticalThick:    '|',
+	Junction:         '+',
+}
+
+// ThemeRounded is like ThemeUTF8 but corners between single rules and single
+// separators are rounded
+var ThemeRounded = Theme{
+	HorizontalSingle: horizontal_single,
+	HorizontalDouble: horizontal_double,
+	HorizontalThick:  horizontal_thick,
+	VerticalSingle:   vertical_single,
+	VerticalDouble:   vertical_double,
+	VerticalThick:    vertical_thick,
+	Junctions: map[rune]rune{
+		'\u250c': '\u256d', // ┌ -> ╭
+		'\u2510': '\u256e', // ┐ -> ╮
+		'\u2514': '\u2570', // └ -> ╰
+		'\u2518': '\u256f', // ┘ -> ╯
+	},
+}
+
+// ThemeHeavy draws single rules and separators as thick ones
+var ThemeHeavy = Theme{
+	HorizontalSingle: horizontal_thick,
+	HorizontalDouble: horizontal_double,
+	HorizontalThick:  horizontal_thick,
+	VerticalSingle:   vertical_thick,
+	VerticalDouble:   vertical_double,
+	VerticalThick:    vertical_thick,
+}
+
+// ThemeNoBorder substitutes all rules and separators by blank characters
+var ThemeNoBorder = Theme{
+	HorizontalSingle: ' ',
+	HorizontalDouble: ' ',
+	HorizontalThick:  ' ',
+	VerticalSingle:   ' ',
+	VerticalDouble:   ' ',
+	VerticalThick:    ' ',
+	Junction:         ' ',
+}
+
+// Runes that do not take any cell in a terminal other than non-spacing and
+// enclosing marks (which include the variation selectors) and format characters
+// (which include the zero width joiner). These are the medial vowels and final
+// consonants of the Hangul Jamo which are combined with the preceding rune
+var zeroWidth = &unicode.RangeTable{
+	R16: []unicode.Range16{
+		{0x1160, 0x11ff, 1},
+		{0xd7b0, 0xd7ff, 1},
+	},
+}
+
 // ----------------------------------------------------------------------------
 // Types
 // ----------------------------------------------------------------------------
@@ -69,6 +299,59 @@ type Table struct {
 	columns []column
 	rows    []row
 	cells   [][]formatter
+
+	// Horizontal rules and vertical separators are stored with the UTF-8 box
+	// drawing characters, and they are drawn with the runes of a theme
+	theme Theme
+
+	// Tables can be given a maximum width (in physical columns) that they try
+	// not to exceed. A value equal to zero means that there is no limit
+	maxwidth int
+
+	// Contents of truncated columns are ended with an ellipsis. If none is
+	// given, then the default one is used
+	ellipsis *string
+
+	// Cells are processed and formatted with methods which do not return
+	// errors. If any is found, it is stored here so that it can be returned
+	// later
+	err error
+}
+
+// StreamWriters draw tables whose rows are written to an io.Writer as soon as
+// they are added. To do this, the width of every column has to be known in
+// advance. StreamWriters are created with NewStreamWriter.
+type StreamWriter struct {
+
+	// A stream writer consists of the columns of the table, each one with its
+	// own specification and fixed width, and the writer where physical lines
+	// are written. Because the splitters of horizontal rules depend on the
+	// lines above and below, it keeps a copy of the last line written (if the
+	// stream has been started) and the rule pending to be written (if any)
+	columns          []column
+	theme            Theme
+	writer           io.Writer
+	last, next       string
+	started, pending bool
+}
+
+// Themes define the runes used to draw tables. Horizontal rules and vertical
+// separators are drawn with the runes given for each type (single, double and
+// thick), and junctions (i.e., the splitters between rules and separators) are
+// computed as if the UTF-8 box drawing characters were used, and then
+// substituted by the rune given in Junctions, if any, or Junction otherwise.
+// Runes equal to zero are drawn with the UTF-8 box drawing characters, so that
+// the zero value is equivalent to ThemeUTF8.
+//
+// All runes of a theme must take one cell in a terminal. Because tables are
+// drawn with the UTF-8 box drawing characters before using the runes of their
+// theme, the contents of the cells can contain any of them, but UTF-8 box
+// drawing characters in the contents are drawn with the theme as well.
+type Theme struct {
+	HorizontalSingle, HorizontalDouble, HorizontalThick rune
+	VerticalSingle, VerticalDouble, VerticalThick       rune
+	Junction                                            rune
+	Junctions                                           map[rune]rune
 }
 
 // columns do not store contents. A column consists then of a vertical separator
@@ -79,12 +362,212 @@ type column struct {
 	sep              string
 	width            int
 	hformat, vformat style
+
+	// columns where numbers are aligned on the decimal point also store the
+	// maximum number of cells taken by the integer part of all numbers
+	// (before) and by the rest of them (after)
+	before, after int
+
+	// hidden columns are not shown when the table is drawn or exported
+	hidden bool
+
+	// if given, the values of all cells of this column are shown with the
+	// text returned by this function instead of using Sprintf
+	format func(any) string
+}
+
+// Errors found while processing column and row specifications are reported
+// with the location of the offending token in the specification and the
+// alternatives that were expected there
+type SpecError struct {
+
+	// the kind of specification ("column" or "row") and the specification
+	// itself
+	Kind string
+	Spec string
+
+	// the byte offset in the specification of the offending token, which is
+	// empty if the end of the specification was reached
+	Offset int
+	Token  string
+
+	// the alternatives that were expected at the given offset
+	Expected []string
+}
+
+// Kinds of locations of a table returned by Table.Cell
+type CellKind int
+
+const (
+	KindContent   CellKind = iota // ordinary contents, including cells
+	KindRule                      // horizontal rule
+	KindMulticell                 // location where a multicell starts
+	KindSpanned                   // location taken by a multicell
+)
+
+// The contents of a location of a table are described with a CellInfo, which
+// is returned by Table.Cell
+type CellInfo struct {
+
+	// the kind of location, and the text shown in it (with ANSI color escape
+	// sequences, if any). Multicells show the text of all their arguments
+	// separated by blanks
+	Kind CellKind
+	Text string
+
+	// ordinary contents also return the original value given by the user,
+	// either directly or with a Cell, which is nil in any other location
+	Value any
+
+	// horizontal rules also return the UTF-8 rune used to draw them, which is
+	// a blank in case no rule is drawn in this location
+	Rule rune
+
+	// multicells return the row and column where they start, and the number
+	// of rows and columns they span. They also return a copy of the table used
+	// to draw them. Any other location spans only itself and has no table
+	Row, Column   int
+	Rows, Columns int
+	Table         *Table
+}
+
+// Comparisons used to sort the rows of a table
+type Comparison int
+
+const (
+	CompareAuto    Comparison = iota // numbers as numbers, others naturally
+	CompareString                    // lexicographic order
+	CompareNumeric                   // numbers first, then strings
+	CompareNatural                   // runs of digits are compared as numbers
+)
+
+// Rows of a table are sorted with a number of keys. Each key refers to a
+// column, whose cells are compared with the given comparison, or with the given
+// function if any is given. Cells are compared with the text they show,
+// without ANSI color escape sequences
+type SortKey struct {
+	Column     int
+	Descending bool
+	Comparison Comparison
+	Compare    func(a, b string) int
+}
+
+// Separators used in the specification of columns built with Column, and in the
+// horizontal rules drawn before footers
+type Separator int
+
+const (
+	Single Separator = iota // │
+	Double                  // ║
+	Thick                   // ┃
+)
+
+// Functions used to aggregate the numeric values of a column
+type Aggregation int
+
+const (
+	AggregateSum   Aggregation = iota // sum of all values
+	AggregateMean                     // arithmetic mean of all values
+	AggregateMin                      // minimum of all values
+	AggregateMax                      // maximum of all values
+	AggregateCount                    // number of values
+)
+
+// Aggregates are computed over the numeric values of a column with the given
+// function, or with the given reducer if any is given. Only values of any
+// numerical type are aggregated, and any other cell is ignored
+type Aggregate struct {
+	Column   int
+	Function Aggregation
+	Reduce   func(values []float64) any
+}
+
+// Footers are rows added to the bottom of a table with the aggregates of some
+// of its columns. They are preceded by a horizontal rule drawn with the given
+// separator, and the label (if any) is shown in the first column which is not
+// aggregated. If Block is true, only the data rows after the last horizontal
+// rule are aggregated, and otherwise all data rows of the table are
+type Footer struct {
+	Aggregates []Aggregate
+	Label      string
+	Rule       Separator
+	Block      bool
+}
+
+// Groups collapse consecutive rows with equal keys in the given column into a
+// multirow with the given vertical alignment ('t', 'c' or 'b', and 'c' by
+// default). Optionally, consecutive groups are separated with horizontal rules,
+// and each group is followed by a subtotal row with the given aggregates of its
+// rows, where the label (if any) is shown in the first column after the key
+// which is not aggregated
+type Group struct {
+	Column     int
+	VAlign     byte
+	Rule       bool
+	Aggregates []Aggregate
+	Label      string
+}
+
+// Records of data in long format used to build pivot tables. Every record has
+// the keys of its row and column, from the outermost to the innermost one, and
+// a value
+type PivotRecord struct {
+	Rows, Columns []any
+	Value         any
+}
+
+// Pivot tables show the aggregates of the values of all records with the same
+// keys, computed with the given function or with the given reducer if any is
+// given. Rows are shown with one column per key, whose titles are given in
+// Titles, and columns are shown under hierarchical headers. Combinations of
+// keys with no records are shown with Fill (or left empty if no fill is
+// given). Optionally, a last column with the totals of every row and a last
+// row with the totals of every column are shown, with the given label ("Total"
+// by default)
+type Pivot struct {
+	Titles       []string
+	Function     Aggregation
+	Reduce       func(values []float64) any
+	Fill         any
+	RowTotals    bool
+	ColumnTotals bool
+	Total        string
+}
+
+// Column specifications can be built programmatically with Column and Last,
+// instead of writing them as strings. Column specifications are values, so
+// that they can be reused and composed, and any error found while building
+// them is reported when they are used
+type ColumnSpec struct {
+
+	// the separator shown before the column, its alignment ('l', 'c', 'r',
+	// 'd' or 'p') and width (if any), whether contents are truncated and its
+	// vertical alignment. The last separator of a table is given as a column
+	// with no alignment
+	sep       string
+	alignment byte
+	width     int
+	truncate  bool
+	valign    byte
+	err       error
 }
 
 // rows do not store contents. A row consists then of a number of physical lines
 // for displaying its contents
 type row struct {
 	height int
+
+	// data rows also store the number of columns whose contents were given,
+	// the rest being automatically filled with empty cells
+	given int
+
+	// footers are never considered when computing aggregates, and footers of
+	// a block aggregate only the rows after the previous horizontal rule.
+	// Subtotals of groups aggregate only the rows of the group whose key is
+	// shown in the given column
+	footer, block bool
+	subtotal      bool
+	key           int
 }
 
 // The style of a cell specifies how to draw it and it is represented typically
@@ -98,11 +581,113 @@ type style struct {
 // Contents are simply strings to be shown on each cell
 type content string
 
+// Cells can be added to a table with their own format, overriding that of the
+// column where they are shown. A zero value in any field means that the
+// corresponding format of the column is used. Cells still participate in the
+// computation of the width of their columns.
+type Cell struct {
+
+	// Value shown in the cell, which is printed as in AddRow
+	Value any
+
+	// Horizontal ('l', 'c' or 'r') and vertical ('t', 'c' or 'b') alignment
+	// of the contents. In columns with a paragraph alignment, the contents are
+	// still split across various lines with the given horizontal alignment
+	HAlign, VAlign byte
+
+	// ANSI escape sequence (e.g., "\033[1;31m") applied to the whole cell,
+	// including its padding. All attributes are reset at the end of the cell
+	Style string
+
+	// Number of blank characters shown before and after the contents
+	PadLeft, PadRight int
+
+	// If positive, the contents are shown in a single line which is truncated
+	// to this number of characters with the ellipsis of the table
+	Truncate int
+}
+
+// Internally, cells given by the user are stored as contents along with their
+// own format and the value given by the user
+type cell struct {
+	text              content
+	data              any
+	halign, valign    byte
+	ansi              string
+	padleft, padright int
+	truncate          int
+}
+
+// Any other item given by the user is stored as a value which retains the
+// original item along with the text used to show it. Values shown in footers
+// store also the aggregate used to compute them again when the table changes
+type value struct {
+	data      any
+	text      content
+	aggregate *Aggregate
+}
+
+// Rows added with Table.AddGroups are computed first as a sequence of lines,
+// each one being either a row of data or a horizontal rule drawn over the given
+// columns. Cells taken by multirows started in previous lines are not given.
+// Lines with subtotals are added as footers with the aggregates of the group
+// whose key is shown in the given column
+type groupLine struct {
+	rule       []int
+	cells      []any
+	taken      []bool
+	subtotal   bool
+	key        int
+	aggregates []Aggregate
+}
+
+// The keys of pivot tables are arranged in trees, where every node stores its
+// key and the nodes with the keys of the next level in order of appearance
+type pivotNode struct {
+	key      any
+	children []*pivotNode
+}
+
 // Both splitters (between horizontal and vertical rules) along with other
 // surrounding characters, and the rune used as a separator above/below other
 // contents are defined as horizontal rules
 type hrule string
 
+// ANSI color escape sequences (i.e., Select Graphic Rendition sequences) modify
+// the way text is shown until they are reset. When exporting tables to other
+// formats, the effect of all sequences processed so far is stored as a
+// graphic rendition, where colors are given as CSS colors, and an empty
+// string means the default color
+type rendition struct {
+	fg, bg                  string
+	bold, italic, underline bool
+}
+
+// Strings are displayed as sequences of glyphs. A glyph consists of a base rune
+// along with all the runes that are combined with it (e.g., combining marks,
+// variation selectors or other runes joined with a zero width joiner) and it is
+// characterized by the number of cells it takes in a terminal, i.e., its
+// width. Every glyph also records its physical location, i.e., the position of
+// its first byte and the position immediately after its last byte
+type glyph struct {
+	r          rune
+	start, end int
+	width      int
+}
+
+// When building tables from Go data, every column is bound either to a field of
+// a struct (given with its index sequence as in reflect.Value.FieldByIndex) or
+// to a key of a map. Columns also store the header shown on top of them, the
+// specification of the column and the order in which they are shown. An order
+// equal to -1 means that no explicit order was given
+type binding struct {
+	index  []int
+	key    string
+	header string
+	spec   string
+	order  int
+}
+
 // Tables can draw cells provided that they can be both processed and formatted:
 // cells are first formatted to generate the physical lines required to display
 // its contents in the form of formatters, which are then formatted one by one
@@ -152,6 +737,26 @@ const (
 	multicell_t
 )
 
+// When exporting the cells of a table (e.g., to CSV), a span policy determines
+// what value is given to each of the cells spanned by a multicell
+type SpanPolicy int
+
+const (
+
+	// SpanFirst shows the contents of the multicell only in the first cell it
+	// spans, the others being empty
+	SpanFirst SpanPolicy = iota
+
+	// SpanRepeat repeats the contents of the multicell in every cell it spans
+	SpanRepeat
+
+	// SpanFlatten lays out the arguments of the multicell (i.e., the cells of
+	// its nested table) over the cells it spans, row by row. If there are
+	// more arguments than cells, the remaining ones are all shown in the last
+	// cell
+	SpanFlatten
+)
+
 // Multicells are used to merge several cells (along rows and/or columns) into
 // one single cell. They are essentially tables with an arbitrary number of
 // columns and rows whose specification is given by the user.
@@ -0,0 +1,244 @@
+// -*- coding: utf-8 -*-
+// edit.go
+// -----------------------------------------------------------------------------
+//
+// Started on <sáb 17-10-2026 01:50:06 (1792201806)>
+//
+
+package table
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ----------------------------------------------------------------------------
+// Table
+// ----------------------------------------------------------------------------
+
+// Methods
+// ----------------------------------------------------------------------------
+
+// -- Private
+
+// return an error if the given row index is out of bounds. If last is true,
+// then the index right after the last row is also accepted
+func (t *Table) verifyRowIndex(irow int, last bool) error {
+
+	if irow < 0 || irow > len(t.rows) || (irow == len(t.rows) && !last) {
+		return fmt.Errorf("The row %v does not exist", irow)
+	}
+	return nil
+}
+
+// return an error if there is any multicell spanning several rows which takes
+// both the irow-th row and the previous one, i.e., if the table can not be
+// split right before the irow-th row
+func (t *Table) verifyMultirows(irow int) error {
+
+	for i := 0; i < irow && i < len(t.cells); i++ {
+		for j := range t.cells[i] {
+			if m, ok := t.cells[i][j].(multicell); ok && m.getNbRows() > 1 && m.getRowInit()+m.getNbRows() > irow {
+				return fmt.Errorf("The row %v can not be modified because the multicell in location (%v, %v) spans several rows",
+					irow, i, j)
+			}
+		}
+	}
+	return nil
+}
+
+// return an error if any of the given cells is a multicell spanning several
+// rows
+func verifyNewMultirows(icells []formatter) error {
+
+	for _, item := range icells {
+		if m, ok := item.(multicell); ok && m.getNbRows() > 1 {
+			return errors.New("Multicells spanning several rows can only be added to the bottom of the table")
+		}
+	}
+	return nil
+}
+
+// update the location of all multicells in this table, i.e., the row and
+// column where they start, after moving rows or columns around. Multicolumns
+// and multirows use also the specification of the column where they are now
+func (t *Table) updateMulticells() {
+
+	for i := range t.cells {
+		for j := range t.cells[i] {
+			if m, ok := t.cells[i][j].(multicell); ok {
+				m.iinit, m.jinit = i, j
+				t.adjustMulticell(m)
+				t.cells[i][j] = m
+			}
+		}
+	}
+}
+
+// compute again the aggregates of all footers, the width of all columns and the
+// height of all data rows from their contents, as if all rows were added again
+// to the table. This is necessary after modifying the contents of the table
+// because its columns and rows might be smaller now
+func (t *Table) recompute() {
+
+	t.updateFooters()
+	for j := range t.columns {
+		t.columns[j].width, t.columns[j].before, t.columns[j].after = 0, 0, 0
+	}
+	for i := range t.cells {
+		if t.isRule(i) {
+			continue
+		}
+
+		// multicells have their own tables, which have to be computed again
+		// as well
+		for _, item := range t.cells[i] {
+			if m, ok := item.(multicell); ok {
+				m.table.recompute()
+			}
+		}
+		t.rows[i].height = t.measureRow(i, t.cells[i][:t.rows[i].given])
+	}
+}
+
+// -- Public
+
+// SetCell sets the contents of the cell in the given row and column, which are
+// shown as in AddRow. Cells can be given also their own format with Cell. The
+// width of all columns and the height of all rows are updated accordingly.
+//
+// It returns an error if the location does not exist, it is a horizontal rule,
+// it is taken by a multicell, or the given value is a multicell. Rows with
+// multicells should be modified with ReplaceRow instead
+func (t *Table) SetCell(irow, jcol int, value any) error {
+
+	if err := t.verifyRowIndex(irow, false); err != nil {
+		return err
+	}
+	if jcol < 0 || jcol >= t.GetNbColumns() {
+		return fmt.Errorf("The column %v does not exist", jcol)
+	}
+	if t.isRule(irow) {
+		return fmt.Errorf("The row %v is a horizontal rule", irow)
+	}
+	if t.getMulticell(irow, jcol) != nil || t.cells[irow][jcol] == nil {
+		return fmt.Errorf("The location (%v, %v) is taken by a multicell", irow, jcol)
+	}
+	if _, ok := value.(multicell); ok {
+		return errors.New("Multicells can not be set with SetCell. Use ReplaceRow instead")
+	}
+
+	// create the new cell and store it in the table
+	item, err := t.newFormatter(value, jcol)
+	if err != nil {
+		return err
+	}
+	t.cells[irow][jcol] = item
+	t.rows[irow].given = max[int](t.rows[irow].given, jcol+1)
+
+	t.recompute()
+	return nil
+}
+
+// InsertRow inserts a new row of data right before the given row, which is
+// created exactly as in AddRow. If the given row is equal to the number of rows
+// of the table, the row is added to the bottom of the table.
+//
+// It returns an error if the row does not exist, the new row would split a
+// multicell spanning several rows, or the new row contains multicells spanning
+// several rows and it is not added to the bottom of the table
+func (t *Table) InsertRow(irow int, cells ...any) error {
+
+	if err := t.verifyRowIndex(irow, true); err != nil {
+		return err
+	}
+	if irow == len(t.rows) {
+		return t.AddRow(cells...)
+	}
+	if err := t.verifyMultirows(irow); err != nil {
+		return err
+	}
+
+	// create the new row
+	icells, given, err := t.newRow(irow, cells...)
+	if err != nil {
+		return err
+	}
+	if err := verifyNewMultirows(icells); err != nil {
+		return err
+	}
+
+	// and insert it in the table
+	t.cells = append(t.cells[:irow], append([][]formatter{icells}, t.cells[irow:]...)...)
+	t.rows = append(t.rows[:irow], append([]row{{given: given}}, t.rows[irow:]...)...)
+	t.updateMulticells()
+
+	t.recompute()
+	return nil
+}
+
+// DeleteRow deletes the given row, either a row of data or a horizontal rule.
+//
+// It returns an error if the row does not exist, or it is taken by a multicell
+// spanning several rows
+func (t *Table) DeleteRow(irow int) error {
+
+	if err := t.verifyRowIndex(irow, false); err != nil {
+		return err
+	}
+	if err := t.verifyMultirows(irow); err != nil {
+		return err
+	}
+	if err := t.verifyMultirows(irow + 1); err != nil {
+		return err
+	}
+
+	// remove the row from the table
+	t.cells = append(t.cells[:irow], t.cells[irow+1:]...)
+	t.rows = append(t.rows[:irow], t.rows[irow+1:]...)
+	t.updateMulticells()
+
+	t.recompute()
+	return nil
+}
+
+// ReplaceRow replaces the given row, either a row of data or a horizontal rule,
+// with a new row of data created exactly as in AddRow.
+//
+// It returns an error if the row does not exist, it is taken by a multicell
+// spanning several rows, or the new row contains multicells spanning several
+// rows and it is not the last row of the table
+func (t *Table) ReplaceRow(irow int, cells ...any) error {
+
+	if err := t.verifyRowIndex(irow, false); err != nil {
+		return err
+	}
+	if err := t.verifyMultirows(irow); err != nil {
+		return err
+	}
+	if err := t.verifyMultirows(irow + 1); err != nil {
+		return err
+	}
+
+	// create the new row
+	icells, given, err := t.newRow(irow, cells...)
+	if err != nil {
+		return err
+	}
+	if irow < len(t.rows)-1 {
+		if err := verifyNewMultirows(icells); err != nil {
+			return err
+		}
+	}
+
+	// and replace the old one
+	t.cells[irow], t.rows[irow] = icells, row{given: given}
+
+	t.recompute()
+	return nil
+}
+
+// Local Variables:
+// mode:go
+// fill-column:80
+// End:
@@ -0,0 +1,137 @@
+// -*- coding: utf-8 -*-
+// edit_test.go
+// -----------------------------------------------------------------------------
+//
+// Started on <sáb 17-10-2026 01:50:06 (1792201806)>
+//
+
+package table
+
+import "testing"
+
+// add the given rows to the given table, where nil rows stand for single
+// horizontal rules
+func addRows(t *Table, rows [][]any) {
+	for _, row := range rows {
+		if row == nil {
+			t.AddSingleRule()
+		} else {
+			t.AddRow(row...)
+		}
+	}
+}
+
+func TestTable_Edit(t *testing.T) {
+
+	// multicells can not be shared among tables, so that rows are created
+	// for every test
+	rows := func() [][]any {
+		return [][]any{
+			{"Name", "Value"},
+			nil,
+			{"a very long name", 1},
+			{Multicolumn(2, "| c", "both columns")},
+			{"b", 2.5},
+		}
+	}
+	tests := []struct {
+		name string
+		edit func(t *Table) error
+		want [][]any
+	}{
+		{name: "set cell",
+			edit: func(t *Table) error { return t.SetCell(2, 0, "a") },
+			want: [][]any{{"Name", "Value"}, nil, {"a", 1}, {Multicolumn(2, "| c", "both columns")}, {"b", 2.5}}},
+		{name: "set cell with format",
+			edit: func(t *Table) error { return t.SetCell(4, 1, Cell{Value: 3, HAlign: 'l'}) },
+			want: [][]any{{"Name", "Value"}, nil, {"a very long name", 1}, {Multicolumn(2, "| c", "both columns")}, {"b", Cell{Value: 3, HAlign: 'l'}}}},
+		{name: "insert row",
+			edit: func(t *Table) error { return t.InsertRow(3, "c", "a very long value") },
+			want: [][]any{{"Name", "Value"}, nil, {"a very long name", 1}, {"c", "a very long value"}, {Multicolumn(2, "| c", "both columns")}, {"b", 2.5}}},
+		{name: "insert row at the bottom",
+			edit: func(t *Table) error { return t.InsertRow(5, "c") },
+			want: [][]any{{"Name", "Value"}, nil, {"a very long name", 1}, {Multicolumn(2, "| c", "both columns")}, {"b", 2.5}, {"c"}}},
+		{name: "delete row",
+			edit: func(t *Table) error { return t.DeleteRow(2) },
+			want: [][]any{{"Name", "Value"}, nil, {Multicolumn(2, "| c", "both columns")}, {"b", 2.5}}},
+		{name: "delete rule",
+			edit: func(t *Table) error { return t.DeleteRow(1) },
+			want: [][]any{{"Name", "Value"}, {"a very long name", 1}, {Multicolumn(2, "| c", "both columns")}, {"b", 2.5}}},
+		{name: "replace row",
+			edit: func(t *Table) error { return t.ReplaceRow(3, "c", 3) },
+			want: [][]any{{"Name", "Value"}, nil, {"a very long name", 1}, {"c", 3}, {"b", 2.5}}},
+		{name: "replace row with a multicolumn",
+			edit: func(t *Table) error { return t.ReplaceRow(2, Multicolumn(2, "| r", "x")) },
+			want: [][]any{{"Name", "Value"}, nil, {Multicolumn(2, "| r", "x")}, {Multicolumn(2, "| c", "both columns")}, {"b", 2.5}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, _ := NewTable("| l | r |")
+			addRows(got, rows())
+
+			// draw the table before editing it, so that it has to be
+			// computed again
+			_ = got.String()
+			if err := tt.edit(got); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			want, _ := NewTable("| l | r |")
+			addRows(want, tt.want)
+			if got.String() != want.String() {
+				t.Fatalf("got\n%v\nwant\n%v", got, want)
+			}
+		})
+	}
+}
+
+func TestTable_Edit_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		edit func(t *Table) error
+	}{
+		{name: "set cell out of bounds",
+			edit: func(t *Table) error { return t.SetCell(9, 0, "x") }},
+		{name: "set cell in an unknown column",
+			edit: func(t *Table) error { return t.SetCell(0, 2, "x") }},
+		{name: "set cell in a rule",
+			edit: func(t *Table) error { return t.SetCell(1, 0, "x") }},
+		{name: "set cell in a multicell",
+			edit: func(t *Table) error { return t.SetCell(3, 0, "x") }},
+		{name: "set cell with a multicell",
+			edit: func(t *Table) error { return t.SetCell(0, 0, Multicolumn(1, "c", "x")) }},
+		{name: "insert row splitting a multirow",
+			edit: func(t *Table) error { return t.InsertRow(3, "x") }},
+		{name: "insert row with a multirow",
+			edit: func(t *Table) error { return t.InsertRow(1, Multirow(2, "c", "x")) }},
+		{name: "delete row in a multirow",
+			edit: func(t *Table) error { return t.DeleteRow(3) }},
+		{name: "delete first row of a multirow",
+			edit: func(t *Table) error { return t.DeleteRow(2) }},
+		{name: "replace row in a multirow",
+			edit: func(t *Table) error { return t.ReplaceRow(3, "x") }},
+		{name: "replace row out of bounds",
+			edit: func(t *Table) error { return t.ReplaceRow(-1, "x") }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table, _ := NewTable("| c | c |")
+			table.AddRow("a", "b")
+			table.AddSingleRule()
+			table.AddRow(Multirow(2, "c", "m"), "c")
+			table.AddRow("d")
+			want := table.String()
+			if err := tt.edit(table); err == nil {
+				t.Fatalf("expected an error")
+			}
+			if got := table.String(); got != want {
+				t.Fatalf("the table was modified:\n%v\nwant\n%v", got, want)
+			}
+		})
+	}
+}
+
+// Local Variables:
+// mode:go
+// fill-column:80
+// End:
@@ -0,0 +1,171 @@
+// -*- coding: utf-8 -*-
+// fit.go
+// -----------------------------------------------------------------------------
+//
+// Started on <sáb 17-10-2026 01:34:16 (1792200856)>
+//
+
+package table
+
+import (
+	"errors"
+	"os"
+	"strconv"
+	"unicode"
+)
+
+// ----------------------------------------------------------------------------
+// Fit
+// ----------------------------------------------------------------------------
+
+// Functions
+// ----------------------------------------------------------------------------
+
+// return the width of the terminal, i.e., the number of cells of every line. It
+// is taken from the environment variable COLUMNS if it is defined and,
+// otherwise, it is requested to the terminal attached to the standard output.
+// In case it is not possible to determine it, 0 is returned
+func getTerminalWidth() int {
+
+	if width, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && width > 0 {
+		return width
+	}
+	return getTerminalSize()
+}
+
+// Methods
+// ----------------------------------------------------------------------------
+
+// -- Private
+
+// replace all multicells of this table with copies whose tables take only the
+// width required by their contents, rather than the width of the columns they
+// span. Note that the cells of the table are copied as well
+func (t *Table) shrinkMulticells() {
+
+	t.cells = append([][]formatter(nil), t.cells...)
+	for i := range t.cells {
+		t.cells[i] = append([]formatter(nil), t.cells[i]...)
+		for j, item := range t.cells[i] {
+			if m, ok := item.(multicell); ok {
+				m.table.columns = append([]column(nil), m.table.columns...)
+				m.table.rows = append([]row(nil), m.table.rows...)
+				m.table.recompute()
+				t.cells[i][j] = m
+			}
+		}
+	}
+}
+
+// shrink the widest columns with no paragraph alignment (l, c, r) so that the
+// overall width of the table does not exceed the given width, if possible.
+// Shrunk columns are transformed into paragraphs with the same alignment (L, C,
+// R) and the height of all rows is updated accordingly. Columns are never
+// shrunk below a minimum width, paragraphs and columns whose contents are
+// either truncated or aligned on the decimal point are never modified, and
+// columns are widened again if necessary to show the contents of multicells.
+//
+// Note that this function modifies the columns and rows of the table, so that
+// it should be invoked over a copy of them. Cells are copied if necessary
+func (t *Table) fitColumns(width int) {
+
+	// compute the excess of the table over the given width
+	excess := t.getColumnsWidth(0, len(t.columns)) - width
+	if excess <= 0 {
+		return
+	}
+
+	// shrink the widest column among those that can be shrunk, one position
+	// at a time until either the table fits or no column can be shrunk
+	shrunk := make([]bool, len(t.columns))
+	for ; excess > 0; excess-- {
+
+		jmax := -1
+		for j := 0; j < t.GetNbColumns(); j++ {
+			if !t.columns[j].hformat.isParagraph() && !t.columns[j].hformat.isTruncated() &&
+				!t.columns[j].hformat.isDecimal() &&
+				t.columns[j].width > min_column_width &&
+				(jmax < 0 || t.columns[j].width > t.columns[jmax].width) {
+				jmax = j
+			}
+		}
+		if jmax < 0 {
+			break
+		}
+		t.columns[jmax].width--
+		shrunk[jmax] = true
+	}
+
+	// columns might have to be widened again to show the contents of
+	// multicells, but only as much as their contents require
+	t.shrinkMulticells()
+	t.distributeAllColumns()
+
+	// and now transform all shrunk columns into paragraphs
+	for j := range t.columns {
+		if shrunk[j] {
+			t.columns[j].hformat = style{
+				alignment: byte(unicode.ToUpper(rune(t.columns[j].hformat.alignment))),
+				arg:       t.columns[j].width,
+			}
+		}
+	}
+
+	// finally, update the height of all data rows, which can only be increased
+	// as contents in the shrunk columns are now split across several lines.
+	// Multicells are not considered as the columns of their own tables are not
+	// modified
+	for irow := 0; irow < len(t.rows); irow++ {
+		if t.isRule(irow) {
+			continue
+		}
+		for j := range t.columns {
+			switch t.cells[irow][j].(type) {
+			case content, cell, value:
+				if shrunk[j] {
+					t.rows[irow].height = max[int](t.rows[irow].height,
+						len(t.cells[irow][j].Process(t, irow, j)))
+				}
+			}
+		}
+	}
+}
+
+// -- Public
+
+// SetMaxWidth sets the maximum width (in physical columns, including
+// separators) of the table. When the table is drawn, if its width exceeds the
+// maximum, then the widest columns with no paragraph alignment ('l', 'c' and
+// 'r') are shrunk and their contents are split across several lines as if they
+// were 'L', 'C' and 'R' columns respectively. Paragraphs ('p', 'L', 'C' and
+// 'R') and columns whose contents are either truncated or aligned on the
+// decimal point ('d') keep their width, and columns are widened again if
+// necessary to show the contents of multicells, so that the table might still
+// exceed the maximum width.
+//
+// A width equal to zero (or less) means that there is no limit, which is the
+// default
+func (t *Table) SetMaxWidth(width int) {
+	t.maxwidth = max[int](0, width)
+}
+
+// FitToTerminal sets the maximum width of the table (see SetMaxWidth) to the
+// width of the terminal, which is taken from the environment variable COLUMNS
+// or, if it is not defined, from the terminal attached to the standard output.
+//
+// In case the width of the terminal can not be determined an error is returned
+// and the maximum width of the table is not modified
+func (t *Table) FitToTerminal() error {
+
+	width := getTerminalWidth()
+	if width == 0 {
+		return errors.New("the width of the terminal could not be determined")
+	}
+	t.SetMaxWidth(width)
+	return nil
+}
+
+// Local Variables:
+// mode:go
+// fill-column:80
+// End:
@@ -0,0 +1,104 @@
+// -*- coding: utf-8 -*-
+// fit_test.go
+// -----------------------------------------------------------------------------
+//
+// Started on <sáb 17-10-2026 01:34:16 (1792200856)>
+//
+
+package table
+
+import "testing"
+
+func TestTable_SetMaxWidth(t *testing.T) {
+	tests := []struct {
+		name  string
+		width int
+		want  string
+	}{
+		{name: "no limit",
+			width: 0,
+			want: `│ A rather long description │ centered text │ fixed  │
+│                           │               │ column │
+├───────────────────────────┼───────────────┼────────┤
+│ short                     │       x       │ y      │`},
+		{name: "large enough",
+			width: 54,
+			want: `│ A rather long description │ centered text │ fixed  │
+│                           │               │ column │
+├───────────────────────────┼───────────────┼────────┤
+│ short                     │       x       │ y      │`},
+		{name: "shrink",
+			width: 36,
+			want: `│ A rather   │  centered  │ fixed  │
+│ long       │    text    │ column │
+│ descriptio │            │        │
+│ n          │            │        │
+├────────────┼────────────┼────────┤
+│ short      │     x      │ y      │`},
+		{name: "minimum width",
+			width: 10,
+			want: `│ A  │ ce │ fixed  │
+│ ra │ nt │ column │
+│ th │ er │        │
+│ er │ ed │        │
+│ lo │ te │        │
+│ ng │ xt │        │
+│ de │    │        │
+│ sc │    │        │
+│ ri │    │        │
+│ pt │    │        │
+│ io │    │        │
+│ n  │    │        │
+├────┼────┼────────┤
+│ sh │ x  │ y      │
+│ or │    │        │
+│ t  │    │        │`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tab, _ := NewTable("| l | c | p{6} |")
+			tab.AddRow("A rather long description", "centered text", "fixed column")
+			tab.AddSingleRule()
+			tab.AddRow("short", "x", "y")
+			tab.SetMaxWidth(tt.width)
+			if got := tab.String(); got != tt.want {
+				t.Errorf("Table.String() =\n%v\nwant\n%v", got, tt.want)
+			}
+
+			// fitting a table does not modify it
+			if got := tab.columns[0].hformat; got != (style{alignment: 'l'}) {
+				t.Errorf("Table.String() modified the style of the first column: %v", got)
+			}
+		})
+	}
+}
+
+func TestTable_SetMaxWidthMulticolumn(t *testing.T) {
+
+	// multicolumns widen columns only as much as their contents require
+	tab, _ := NewTable("| l | l |")
+	tab.AddRow("A rather long description", "Another long description")
+	tab.AddSingleRule()
+	tab.AddRow(Multicolumn(2, "| c |", "short"))
+	tab.SetMaxWidth(30)
+	want := `│ A rather    │ Another long │
+│ long        │ description  │
+│ description │              │
+├─────────────┴──────────────┤
+│           short            │`
+	if got := tab.String(); got != want {
+		t.Errorf("Table.String() =\n%v\nwant\n%v", got, want)
+	}
+}
+
+func TestTable_FitToTerminal(t *testing.T) {
+
+	tab, _ := NewTable("l")
+	t.Setenv("COLUMNS", "42")
+	if err := tab.FitToTerminal(); err != nil {
+		t.Fatalf("Table.FitToTerminal() = %v", err)
+	}
+	if tab.maxwidth != 42 {
+		t.Errorf("Table.FitToTerminal() set a maximum width equal to %v, want 42", tab.maxwidth)
+	}
+}
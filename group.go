@@ -0,0 +1,305 @@
+// -*- coding: utf-8 -*-
+// group.go
+// -----------------------------------------------------------------------------
+//
+// Started on <sáb 17-10-2026 01:59:31 (1792202371)>
+//
+
+package table
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ----------------------------------------------------------------------------
+// Group
+// ----------------------------------------------------------------------------
+
+// Functions
+// ----------------------------------------------------------------------------
+
+// -- Private
+
+// return the value given in the specified item, which is the value of a Cell
+// or the item itself otherwise
+func getValue(item any) any {
+
+	if c, ok := item.(Cell); ok {
+		return c.Value
+	}
+	return item
+}
+
+// return the key used to compare the given item with others when grouping
+// rows, i.e., the string that represents it
+func getGroupKey(item any) string {
+	return fmt.Sprintf("%v", item)
+}
+
+// Methods
+// ----------------------------------------------------------------------------
+
+// -- Private
+
+// verify that the rows of this table can be grouped with the given levels
+func (t *Table) verifyGroups(levels []Group) error {
+
+	if len(levels) == 0 {
+		return errors.New("At least one group must be given")
+	}
+	for l, level := range levels {
+		if level.Column < 0 || level.Column >= t.GetNbColumns() {
+			return fmt.Errorf("The column %v used to group rows does not exist", level.Column)
+		}
+		if l > 0 && level.Column <= levels[l-1].Column {
+			return errors.New("The columns used to group rows must be given in increasing order")
+		}
+		if level.VAlign != 0 && level.VAlign != 't' && level.VAlign != 'c' && level.VAlign != 'b' {
+			return fmt.Errorf("'%c' is an incorrect vertical format", level.VAlign)
+		}
+
+		// subtotals can be computed only in the columns after the key
+		aggregated := make([]bool, t.GetNbColumns())
+		for _, aggregate := range level.Aggregates {
+			if err := aggregate.verify(t.GetNbColumns()); err != nil {
+				return err
+			}
+			if aggregate.Column <= level.Column {
+				return fmt.Errorf("The column %v can not be aggregated in the subtotals of the groups of column %v",
+					aggregate.Column, level.Column)
+			}
+			if aggregated[aggregate.Column] {
+				return fmt.Errorf("The column %v is aggregated more than once", aggregate.Column)
+			}
+			aggregated[aggregate.Column] = true
+		}
+	}
+	return nil
+}
+
+// return the line with the subtotals of the given records, which form a group
+// of the given level
+func (t *Table) getSubtotal(records [][]any, level Group) groupLine {
+
+	line := groupLine{
+		cells:      make([]any, t.GetNbColumns()),
+		taken:      make([]bool, t.GetNbColumns()),
+		subtotal:   true,
+		key:        level.Column,
+		aggregates: level.Aggregates,
+	}
+
+	// the label is shown in the first column after the key which is not
+	// aggregated, and the rest of them are left empty
+	aggregated := make([]bool, t.GetNbColumns())
+	for _, aggregate := range level.Aggregates {
+		aggregated[aggregate.Column] = true
+	}
+	for j := range line.cells {
+		line.cells[j] = ""
+	}
+	for j := level.Column + 1; j < t.GetNbColumns(); j++ {
+		if !aggregated[j] {
+			line.cells[j] = level.Label
+			break
+		}
+	}
+
+	// compute all aggregates over the numeric values of all records
+	for _, aggregate := range level.Aggregates {
+		var values []float64
+		for _, record := range records {
+			if value, ok := toNumber(getValue(record[aggregate.Column])); ok {
+				values = append(values, value)
+			}
+		}
+		line.cells[aggregate.Column] = aggregate.compute(values)
+	}
+	return line
+}
+
+// return the lines used to show the given records grouped with the given
+// levels. The key of every group is shown in a multirow which spans all the
+// lines of the group, including the rules and subtotals of the groups nested
+// in it, and its own subtotal
+func (t *Table) getGroupLines(records [][]any, levels []Group) ([]groupLine, error) {
+
+	var lines []groupLine
+
+	// in case there are no more levels, every record is shown in a line of its
+	// own
+	if len(levels) == 0 {
+		for _, record := range records {
+			lines = append(lines, groupLine{
+				cells: append([]any(nil), record...),
+				taken: make([]bool, len(record)),
+			})
+		}
+		return lines, nil
+	}
+
+	// otherwise, compute the groups of consecutive records with equal keys
+	level := levels[0]
+	valign := "c"
+	if level.VAlign != 0 {
+		valign = string(level.VAlign)
+	}
+	for init := 0; init < len(records); {
+		end := init + 1
+		for end < len(records) &&
+			getGroupKey(records[end][level.Column]) == getGroupKey(records[init][level.Column]) {
+			end++
+		}
+
+		// compute the lines of the nested groups and the subtotals of this
+		// one, if any is requested
+		group, err := t.getGroupLines(records[init:end], levels[1:])
+		if err != nil {
+			return nil, err
+		}
+		if len(level.Aggregates) > 0 {
+			group = append(group, groupLine{rule: []int{level.Column + 1, t.GetNbColumns()}},
+				t.getSubtotal(records[init:end], level))
+		}
+
+		// the key is shown only once in the first line, and all the other
+		// lines leave its column to the multirow
+		key := records[init][level.Column]
+		if len(group) > 1 {
+			m, err := NewMultirow(len(group), valign, key)
+			if err != nil {
+				return nil, err
+			}
+			key = m
+		}
+		group[0].cells[level.Column] = key
+		for i := 1; i < len(group); i++ {
+			if group[i].rule == nil {
+				group[i].taken[level.Column] = true
+			}
+		}
+
+		// and separate this group from the previous one if requested
+		if level.Rule && init > 0 {
+			lines = append(lines, groupLine{rule: []int{level.Column, t.GetNbColumns()}})
+		}
+		lines = append(lines, group...)
+		init = end
+	}
+	return lines, nil
+}
+
+// add the given lines to the bottom of this table. It returns an error if any
+// line can not be added
+func (t *Table) addGroupLines(lines []groupLine) error {
+
+	for _, line := range lines {
+		if line.rule != nil {
+			if err := t.addRule(hrule(horizontal_single), line.rule...); err != nil {
+				return err
+			}
+			continue
+		}
+		var cells []any
+		for j, item := range line.cells {
+			if !line.taken[j] {
+				cells = append(cells, item)
+			}
+		}
+		if err := t.AddRow(cells...); err != nil {
+			return err
+		}
+		if !line.subtotal {
+			continue
+		}
+
+		// subtotals record their aggregates so that they are computed again
+		// whenever the table changes
+		irow := len(t.rows) - 1
+		t.rows[irow].footer, t.rows[irow].subtotal, t.rows[irow].key = true, true, line.key
+		for _, aggregate := range line.aggregates {
+			aggregate := aggregate
+			if v, ok := t.cells[irow][aggregate.Column].(value); ok {
+				v.aggregate = &aggregate
+				t.cells[irow][aggregate.Column] = v
+			}
+		}
+	}
+	return nil
+}
+
+// -- Public
+
+// AddGroups adds the given records to the bottom of the table, each one with
+// the contents of all columns as given to AddRow, grouped with the given
+// levels. Consecutive records with the same key in the column of the first
+// level are collapsed into a multirow; records within every group are grouped
+// in turn with the next level, and so on. Optionally, consecutive groups of
+// every level are separated with horizontal rules drawn from their column to
+// the right end of the table, and groups are followed by subtotal rows which
+// are preceded by a horizontal rule drawn from the column after their key.
+// Records are not sorted, and subtotal rows are added as footers, so that they
+// are not aggregated by AddFooter and they are computed again whenever the
+// table is modified, e.g., with SetCell.
+//
+// It returns an error if no level is given, the columns of the levels do not
+// exist or they are not given in increasing order, any aggregate can not be
+// computed, any record has more cells than columns, contains multicells or
+// cells that are not correct, or there are multirows spanning rows which have
+// not been added yet. In case of error, the table is not modified
+func (t *Table) AddGroups(records [][]any, levels ...Group) error {
+
+	if err := t.verifyGroups(levels); err != nil {
+		return err
+	}
+	for j := 0; j < t.GetNbColumns(); j++ {
+		if t.hasMulticell(len(t.rows), j) {
+			return fmt.Errorf("The multicell in column %v spans rows which have not been added yet", j)
+		}
+	}
+
+	// copy all records with the contents of all columns, so that the last
+	// columns are empty if they were not given
+	rows := make([][]any, len(records))
+	for i, record := range records {
+		if len(record) > t.GetNbColumns() {
+			return fmt.Errorf("The record %v has more than %v cells", i, t.GetNbColumns())
+		}
+		rows[i] = make([]any, t.GetNbColumns())
+		for j := range rows[i] {
+			rows[i][j] = ""
+		}
+		for j, item := range record {
+			switch c := item.(type) {
+			case multicell:
+				return fmt.Errorf("The record %v contains multicells which can not be grouped", i)
+			case Cell:
+				if _, err := newCell(c); err != nil {
+					return err
+				}
+			}
+			rows[i][j] = item
+		}
+	}
+
+	// compute all the lines to add and add them to the table. Because lines
+	// are only appended to the table, all of them are removed in case of
+	// error, and the width of all columns is computed again
+	lines, err := t.getGroupLines(rows, levels)
+	if err != nil {
+		return err
+	}
+	nbrows := len(t.rows)
+	if err := t.addGroupLines(lines); err != nil {
+		t.cells, t.rows = t.cells[:nbrows], t.rows[:nbrows]
+		t.recompute()
+		return err
+	}
+	return nil
+}
+
+// Local Variables:
+// mode:go
+// fill-column:80
+// End:
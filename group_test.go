@@ -0,0 +1,180 @@
+// -*- coding: utf-8 -*-
+// group_test.go
+// -----------------------------------------------------------------------------
+//
+// Started on <sáb 17-10-2026 01:59:31 (1792202371)>
+//
+
+package table
+
+import (
+	"testing"
+)
+
+func TestTable_AddGroups(t *testing.T) {
+
+	records := [][]any{
+		{"North", "A", "x", 1},
+		{"North", "A", "y", 2},
+		{"North", "B", "z", 3},
+		{"South", "C", "w", Cell{Value: 4}},
+	}
+
+	tests := []struct {
+		name    string
+		records [][]any
+		levels  []Group
+		want    string
+		wantErr bool
+	}{
+		{name: "single",
+			records: records,
+			levels:  []Group{{Column: 0}},
+			want: `│ Region │ Team │ Name │ Sales │
+├────────┼──────┼──────┼───────┤
+│        │ A    │ x    │     1 │
+│ North  │ A    │ y    │     2 │
+│        │ B    │ z    │     3 │
+│ South  │ C    │ w    │     4 │
+├────────┼──────┼──────┼───────┤
+│ Total  │      │      │    10 │`},
+		{name: "nested",
+			records: records,
+			levels: []Group{
+				{Column: 0, Rule: true, Label: "Subtotal", Aggregates: []Aggregate{{Column: 3}}},
+				{Column: 1, Rule: true}},
+			want: `│ Region │ Team     │ Name │ Sales │
+├────────┼──────────┼──────┼───────┤
+│        │ A        │ x    │     1 │
+│        │          │ y    │     2 │
+│ North  ├──────────┼──────┼───────┤
+│        │ B        │ z    │     3 │
+│        ├──────────┼──────┼───────┤
+│        │ Subtotal │      │     6 │
+├────────┼──────────┼──────┼───────┤
+│        │ C        │ w    │     4 │
+│ South  ├──────────┼──────┼───────┤
+│        │ Subtotal │      │     4 │
+├────────┼──────────┼──────┼───────┤
+│ Total  │          │      │    10 │`},
+		{name: "subtotals",
+			records: [][]any{{"North", "A"}, {"North", "A", "y", 2}},
+			levels: []Group{
+				{Column: 0},
+				{Column: 1, VAlign: 't', Aggregates: []Aggregate{{Column: 3, Function: AggregateCount}}}},
+			want: `│ Region │ Team │ Name │ Sales │
+├────────┼──────┼──────┼───────┤
+│        │ A    │      │       │
+│ North  │      │ y    │     2 │
+│        │      ├──────┼───────┤
+│        │      │      │     1 │
+├────────┼──────┼──────┼───────┤
+│ Total  │      │      │     2 │`},
+		{name: "empty",
+			levels: []Group{{Column: 0}}},
+		{name: "none",
+			records: records,
+			wantErr: true},
+		{name: "column",
+			records: records,
+			levels:  []Group{{Column: 4}},
+			wantErr: true},
+		{name: "order",
+			records: records,
+			levels:  []Group{{Column: 1}, {Column: 0}},
+			wantErr: true},
+		{name: "valign",
+			records: records,
+			levels:  []Group{{Column: 0, VAlign: 'x'}},
+			wantErr: true},
+		{name: "aggregate",
+			records: records,
+			levels:  []Group{{Column: 1, Aggregates: []Aggregate{{Column: 0}}}},
+			wantErr: true},
+		{name: "record",
+			records: [][]any{{"North", "A", "x", 1, 2}},
+			levels:  []Group{{Column: 0}},
+			wantErr: true},
+		{name: "multicell",
+			records: [][]any{{"North", Multicolumn(2, "c", "A"), 1}},
+			levels:  []Group{{Column: 0}},
+			wantErr: true},
+		{name: "cell",
+			records: [][]any{{"North", Cell{Value: "A", HAlign: 'x'}}},
+			levels:  []Group{{Column: 0}},
+			wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table, _ := NewTable("│ l │ l │ l │ r │")
+			table.AddRow("Region", "Team", "Name", "Sales")
+			table.AddSingleRule()
+			before := table.String()
+
+			err := table.AddGroups(tt.records, tt.levels...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("AddGroups() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil || tt.want == "" {
+				if table.GetNbRows() != 2 {
+					t.Errorf("AddGroups() added %v rows", table.GetNbRows()-2)
+				}
+				if got := table.String(); got != before {
+					t.Errorf("String() =\n%v\nwant\n%v", got, before)
+				}
+				return
+			}
+
+			// subtotals are never aggregated by footers
+			table.AddFooter(Footer{Label: "Total", Aggregates: []Aggregate{{Column: 3}}})
+			if got := table.String(); got != tt.want {
+				t.Errorf("String() =\n%v\nwant\n%v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTable_AddGroupsUpdate(t *testing.T) {
+
+	table, _ := NewTable("│ l │ l │ r │")
+	table.AddRow("Region", "City", "Sales")
+	table.AddSingleRule()
+	table.AddGroups([][]any{
+		{"North", "Oslo", 1},
+		{"North", "Bergen", 2},
+		{"South", "Rome", 4}},
+		Group{Column: 0, Rule: true, Label: "Subtotal", Aggregates: []Aggregate{{Column: 2}}})
+	table.AddFooter(Footer{Label: "Total", Aggregates: []Aggregate{{Column: 2}}})
+
+	// subtotals are computed again after modifying the rows of their groups,
+	// and also after inserting and deleting rows elsewhere
+	if err := table.SetCell(2, 2, 100); err != nil {
+		t.Fatalf("SetCell() error = %v", err)
+	}
+	if err := table.InsertRow(1, "Area", "Town", "Units"); err != nil {
+		t.Fatalf("InsertRow() error = %v", err)
+	}
+	if err := table.DeleteRow(0); err != nil {
+		t.Fatalf("DeleteRow() error = %v", err)
+	}
+	want := `│ Area  │ Town     │ Units │
+├───────┼──────────┼───────┤
+│       │ Oslo     │   100 │
+│ North │ Bergen   │     2 │
+│       ├──────────┼───────┤
+│       │ Subtotal │   102 │
+├───────┼──────────┼───────┤
+│       │ Rome     │     4 │
+│ South ├──────────┼───────┤
+│       │ Subtotal │     4 │
+├───────┼──────────┼───────┤
+│ Total │          │   106 │`
+	if got := table.String(); got != want {
+		t.Errorf("String() =\n%v\nwant\n%v", got, want)
+	}
+}
+
+// Local Variables:
+// mode:go
+// fill-column:80
+// End:
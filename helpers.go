@@ -38,44 +38,70 @@ func max[T constraints.Ordered](n, m int) int {
 }
 
 // process the given column specification and return a slice of instances of
-// columns properly initialized. In case the parsing was not possible an error
-// is returned
+// columns properly initialized. In case the parsing was not possible a
+// *SpecError is returned
 func getColumns(colspec string) ([]column, error) {
 
 	// --initialization
 	var columns []column
+	var offset int
 
 	// the specification is processed with a regular expression which should be
 	// used to consume the whole string
 	re := regexp.MustCompile(colSpecRegex)
+	spec := colspec
 	for {
 
 		// get the next column and, if none is found, then exit
-		recol := re.FindStringIndex(colspec)
+		recol := re.FindStringIndex(spec)
 		if recol == nil {
 			break
 		}
 
 		// in case creating the new column raises an error then return the
-		// provisional columns and the error
-		nxtcol, err := newColumn(colspec[recol[0]:recol[1]])
+		// error located at the format of the column. Note that the only
+		// error that can happen at this point is an incorrect numerical
+		// argument
+		nxtcol, err := newColumn(spec[recol[0]:recol[1]])
 		if err != nil {
-			return []column{}, err
+			smatch := regexp.MustCompile(columnSpecRegex).FindStringIndex(spec)
+			return []column{}, &SpecError{Kind: "column",
+				Spec:     colspec,
+				Offset:   offset + smatch[0],
+				Token:    spec[smatch[0]:smatch[1]],
+				Expected: []string{"a positive integer as argument"}}
+		}
+
+		// columns aligned on the decimal point can not be next to other
+		// letters, as they are most likely part of the text of a separator
+		if nxtcol.hformat.isDecimal() {
+			if err := verifyDecimalColumn(colspec, offset+recol[0], offset+recol[1]-1); err != nil {
+				return []column{}, err
+			}
 		}
 
 		// add the new column to the slice of columns to return
 		columns = append(columns, *nxtcol)
 
 		// and now move forward in the column specification string
-		colspec = colspec[recol[1]:]
+		spec = spec[recol[1]:]
+		offset += recol[1]
+	}
+
+	// at least one column with contents has to be given
+	if len(columns) == 0 {
+		return []column{}, newSpecError("column", colspec, len(colspec), colSpecifiers)
 	}
 
 	// maybe the column specification string is not empty here. Any remainings
 	// are interpreted as the separator of a last column which contains no text
 	// and which has no format
-	if colspec != "" {
+	if spec != "" {
+		if err := verifyLastSeparator(colspec, offset); err != nil {
+			return []column{}, err
+		}
 		columns = append(columns,
-			column{sep: colspec,
+			column{sep: spec,
 				hformat: style{},
 				vformat: style{}})
 	}
@@ -92,12 +118,64 @@ func separatorToUTF8(input *string) {
 	*input = strings.ReplaceAll(*input, "|", "│")
 }
 
+// return a *SpecError of the given kind of specification located at the given
+// offset. The offending token is the character found at the offset, if any
+func newSpecError(kind, spec string, offset int, expected []string) *SpecError {
+
+	var token string
+	if offset < len(spec) {
+		_, size := utf8.DecodeRuneInString(spec[offset:])
+		token = spec[offset : offset+size]
+	}
+	return &SpecError{Kind: kind,
+		Spec:     spec,
+		Offset:   offset,
+		Token:    token,
+		Expected: expected}
+}
+
+// return a *SpecError if the last separator of the given column specification,
+// which starts at the given offset, contains any column specifier, e.g., a 'p'
+// with no argument, and nil otherwise
+func verifyLastSeparator(colspec string, offset int) error {
+
+	idx := strings.IndexAny(colspec[offset:], "clrdCLRp")
+	if idx < 0 {
+		return nil
+	}
+	offset += idx
+	token := regexp.MustCompile(badSpecRegex).FindString(colspec[offset:])
+	return &SpecError{Kind: "column",
+		Spec:     colspec,
+		Offset:   offset,
+		Token:    token,
+		Expected: colSpecifiers}
+}
+
+// return a *SpecError if the column aligned on the decimal point found at the
+// given offset of the column specification, whose separator starts at init, is
+// ambiguous, i.e., if its separator ends with a letter, or it is followed by a
+// letter which is not a column specifier, e.g., "id" or "dx", and nil
+// otherwise. Such a 'd' was part of the text of a separator before decimal
+// columns were available
+func verifyDecimalColumn(colspec string, init, offset int) error {
+
+	prev, _ := utf8.DecodeLastRuneInString(colspec[init:offset])
+	next, _ := utf8.DecodeRuneInString(colspec[offset+1:])
+	if unicode.IsLetter(prev) || (unicode.IsLetter(next) && !strings.ContainsRune("clrdCLRp", next)) {
+		return newSpecError("column", colspec, offset, []string{decimalSpecifier})
+	}
+	return nil
+}
+
 // process the given specification according to the specified regex (which must
 // match either a column or row specification) and return: first, a new one
 // which has removed the specification of the last column/row if and only if a
 // last column/row with no column/row specifier was given; second, the separator
-// of the last column/row that was removed in the first place
-func stripLastSeparator(colspec string, rexp string) (string, string) {
+// of the last column/row that was removed in the first place. In case the last
+// separator of a column specification contains any column specifier, a
+// *SpecError is returned
+func stripLastSeparator(colspec string, rexp string) (string, string, error) {
 
 	// -- initialization
 	var output string
@@ -106,25 +184,34 @@ func stripLastSeparator(colspec string, rexp string) (string, string) {
 	// parts matching the regular expression are returned so that if a last
 	// column with no specifier is given, it is not added to the result
 	re := regexp.MustCompile(rexp)
+	spec := colspec
 	for {
 
 		// get the next column and, if none is found, then exit
-		recol := re.FindStringIndex(colspec)
+		recol := re.FindStringIndex(spec)
 		if recol == nil {
 			break
 		}
 
 		// copy this part into the output
-		output += colspec[recol[0]:recol[1]]
+		output += spec[recol[0]:recol[1]]
 
 		// and move forward in the column specification string
-		colspec = colspec[recol[1]:]
+		spec = spec[recol[1]:]
+	}
+
+	// column specifiers can not appear in the last separator of a column
+	// specification. Note that this is not possible in row specifications
+	if rexp == colSpecRegex {
+		if err := verifyLastSeparator(colspec, len(output)); err != nil {
+			return "", "", err
+		}
 	}
 
 	// and return the string computed so far substituting the last separator by
 	// its corresponding UTF-8 runes
-	separatorToUTF8(&colspec)
-	return output, colspec
+	separatorToUTF8(&spec)
+	return output, spec, nil
 }
 
 // return a pointer to the preceding multicell in the i-th row which reaches the
@@ -172,13 +259,77 @@ func strToContent(input []string) (output []content) {
 	return
 }
 
-// Return the number of runes in the given string which are both printable and
-// graphic. It also skips color ANSI codes
-func countPrintableRuneInString(s string) (count int) {
+// Return a copy of the given string where all ANSI color codes have been removed
+func stripANSIColors(s string) string {
+	return regexp.MustCompile(ansiColorRegex).ReplaceAllString(s, "")
+}
+
+// Return the text shown in the given cell. Contents are returned as they were
+// given (also in cells with their own format), whereas the text of a multicell
+// is the concatenation of all its arguments separated by blanks. Horizontal
+// rules and cells which are occupied by other multicells have no text at all.
+func getCellContents(item formatter) string {
+
+	switch c := item.(type) {
+	case cell:
+		return string(c.text)
+	case content:
+		return string(c)
+	case value:
+		return string(c.text)
+	case multicell:
+		var args []string
+		for _, arg := range c.args {
+			args = append(args, fmt.Sprintf("%v", arg))
+		}
+		return strings.Join(args, " ")
+	}
+	return ""
+}
+
+// Return the text shown in the given cell with no ANSI color codes
+func getCellText(item formatter) string {
+	return stripANSIColors(getCellContents(item))
+}
+
+// Return the number of cells taken in a terminal by the given rune when it is
+// shown in isolation: wide and fullwidth runes take two cells, non-spacing and
+// enclosing marks, format characters and, in general, all runes which are not
+// graphic take none, whereas the rest take one
+func runeWidth(r rune) int {
+
+	// runes which are not graphic (e.g., control characters) and those which
+	// are combined with the preceding one take no space at all
+	if !unicode.IsGraphic(r) ||
+		unicode.In(r, unicode.Mn, unicode.Me, unicode.Cf, zeroWidth) {
+		return 0
+	}
+
+	// East Asian wide and fullwidth runes take two cells
+	if unicode.Is(eastAsianWide, r) {
+		return 2
+	}
+
+	// all other runes take just one cell
+	return 1
+}
+
+// Return a slice with all glyphs in the given string. ANSI color codes are
+// skipped so that they do not belong to any glyph.
+//
+// Runes which take no space are added to the preceding glyph, and the width of
+// the glyph is then updated in case it is necessary: a variation selector for
+// an emoji presentation widens a narrow glyph, whereas a variation selector for
+// a text presentation narrows a wide symbol. Likewise, runes joined with a zero
+// width joiner to a preceding wide glyph and emoji modifiers (e.g., skin tones)
+// following a wide glyph are considered to be part of it. Control characters,
+// however, are always returned as separate glyphs with no width
+func getGlyphs(s string) (glyphs []glyph) {
 
 	// -- initialization: idx is used to count physical runes, i.e., the
-	// physical location of each rune considering also the ANSI color codes
-	idx := 0
+	// physical location of each rune considering also the ANSI color codes.
+	// joined is true if and only if the previous rune was a zero width joiner
+	idx, joined := 0, false
 
 	// regular expression used to recognize ANSI color codes
 	re := regexp.MustCompile(ansiColorRegex)
@@ -194,69 +345,124 @@ func countPrintableRuneInString(s string) (count int) {
 			// move to the next match of the ANSI color codes
 			idx = colindexes[colind][1]
 			colind++
-		} else {
+			continue
+		}
 
-			// get the rune at the current position, and count it in case it is
-			// both printable and graphic
-			r, size := utf8.DecodeRuneInString(s[idx:])
-			if unicode.IsGraphic(r) && unicode.IsPrint(r) {
-				count++
+		// get the rune at the current position and the number of cells it
+		// takes
+		r, size := utf8.DecodeRuneInString(s[idx:])
+		width := runeWidth(r)
+
+		// get a pointer to the last glyph, if any
+		var last *glyph
+		if len(glyphs) > 0 {
+			last = &glyphs[len(glyphs)-1]
+		}
+
+		// decide whether this rune has to be combined with the previous glyph
+		// or not
+		if last != nil && !unicode.IsControl(r) && !unicode.IsControl(last.r) &&
+			(width == 0 ||
+				(joined && last.width == 2) ||
+				(r >= emoji_modifier_first && r <= emoji_modifier_last && last.width == 2)) {
+
+			// in case this is a variation selector, update the width of the
+			// glyph
+			if r == variation_selector16 && last.width == 1 {
+				last.width = 2
+			}
+			if r == variation_selector15 && last.width == 2 && unicode.Is(unicode.So, last.r) {
+				last.width = 1
 			}
+			last.end = idx + size
+		} else {
 
-			// and move forward
-			idx += size
+			// otherwise, this rune starts a new glyph
+			glyphs = append(glyphs, glyph{r: r, start: idx, end: idx + size, width: width})
 		}
+
+		// remember whether this rune is a zero width joiner and move forward
+		joined = r == zero_width_joiner
+		idx += size
+	}
+
+	return
+}
+
+// Return the number of cells taken in a terminal by the given string, i.e., its
+// display width. It takes into account East Asian wide and fullwidth runes,
+// combining marks, variation selectors, zero width joiners and it also skips
+// color ANSI codes
+func countPrintableRuneInString(s string) (count int) {
+
+	// just add the width of all glyphs in the string
+	for _, g := range getGlyphs(s) {
+		count += g.width
 	}
 
 	return
 }
 
 // the following function returns a slice of strings with the same contents than
-// the input string (with some spaces removed) such that the length of each
-// string is the larger one less or equal than the given width
+// the input string (with some spaces removed) such that the width of each
+// string is the larger one less or equal than the given width. Widths are
+// measured in cells so that wide glyphs are never split between two lines
 func splitParagraph(str string, width int) (result []string) {
 
-	// iterate over all runes of the input string
+	// iterate over all glyphs of the input string
 	for len(str) > 0 {
 
-		// while processing a substring, keep track of the number of runes in it
-		// and also the location of the last byte to add to it. In addition, it
-		// is required to store the position of the rune to start considering in
-		// the next cycle
-		var nbrunes, end, nxt int
-		for pos, rune := range str {
+		// while processing a substring, keep track of the number of cells it
+		// takes and also the location of the last byte to add to it. In
+		// addition, it is required to store the position of the byte to start
+		// considering in the next cycle
+		var nbcells, end, nxt int
+		glyphs := getGlyphs(str)
+		for idx, g := range glyphs {
+
+			// in case this glyph does not fit in the current substring then
+			// break before it, unless it is the first one so that it is
+			// guaranteed that every substring consumes at least one glyph
+			if nbcells > 0 && nbcells+g.width > width {
+
+				// if no breaking point has been found before then add all
+				// glyphs until the current location
+				if end == 0 {
+					end, nxt = g.start, 0
+				}
+				break
+			}
 
-			// accept this rune
-			nbrunes++
+			// accept this glyph
+			nbcells += g.width
 
 			// in case this is a space (including utf-8 spaces) then remember
 			// the location of the last position to include in the current
 			// substring
-			if unicode.IsSpace(rune) {
-				end, nxt = pos, utf8.RuneLen(rune)
+			if unicode.IsSpace(g.r) {
+				end, nxt = g.start, g.end-g.start
 
 				// and, in case this is a newline character, then exit
 				// immediately from the inner loop
-				if rune == '\n' {
+				if g.r == '\n' {
 					break
 				}
 			}
 
-			// If the maximum number of runes to add has been reached then break
-			// avoiding adding more runes
-			if nbrunes >= width {
+			// If the maximum number of cells to add has been reached then break
+			// avoiding adding more glyphs
+			if nbcells >= width {
 
-				// if no breaking point has been found before then add all runes
-				// until the current location
+				// if no breaking point has been found before then add all
+				// glyphs until the current location
 				if end == 0 {
-					end, nxt = pos+utf8.RuneLen(rune), 0
+					end, nxt = g.end, 0
 				}
 
-				// If the character immediately after this one is a space then
-				// add all runes until this location also
-				nxtrune, _ := utf8.DecodeRuneInString(str[pos+utf8.RuneLen(rune):])
-				if unicode.IsSpace(nxtrune) {
-					end, nxt = pos+utf8.RuneLen(rune), utf8.RuneLen(rune)
+				// If the glyph immediately after this one is a space then add
+				// all glyphs until this location also
+				if idx+1 < len(glyphs) && unicode.IsSpace(glyphs[idx+1].r) {
+					end, nxt = g.end, glyphs[idx+1].end-glyphs[idx+1].start
 				}
 
 				break
@@ -264,11 +470,17 @@ func splitParagraph(str string, width int) (result []string) {
 
 			// Finally, if the whole string has been exhausted, then add it
 			// until the end
-			if pos+utf8.RuneLen(rune) >= len(str) {
+			if idx == len(glyphs)-1 {
 				end, nxt = len(str), 0
 			}
 		}
 
+		// if the string contains no glyphs at all (e.g., it consists only of
+		// ANSI color codes) then add it entirely
+		if len(glyphs) == 0 {
+			end, nxt = len(str), 0
+		}
+
 		// add the substring from the beginning of the input string until the
 		// end
 		result = append(result, str[:end])
@@ -280,19 +492,76 @@ func splitParagraph(str string, width int) (result []string) {
 	return
 }
 
+// return the given string truncated so that it takes no more cells than the
+// given width. In case it is truncated (either because it is too wide or
+// because it contains more than one line), the given ellipsis is added at its
+// end provided that it fits. ANSI color codes are never cut and, if any color
+// was active at the truncation point, all attributes are reset at the end
+func truncateString(str string, width int, ellipsis string) string {
+
+	// first, verify whether the string has to be truncated at all
+	var nbcells int
+	var truncate bool
+	glyphs := getGlyphs(str)
+	for _, g := range glyphs {
+		if g.r == '\n' || nbcells+g.width > width {
+			truncate = true
+			break
+		}
+		nbcells += g.width
+	}
+	if !truncate {
+		return str
+	}
+
+	// the ellipsis is used only in case it fits in the given width
+	if countPrintableRuneInString(ellipsis) > width {
+		ellipsis = ""
+	}
+	available := width - countPrintableRuneInString(ellipsis)
+
+	// look for the first glyph that can not be shown. Because the string has to
+	// be truncated, it is guaranteed to exist
+	var end int
+	for nbcells = 0; end < len(glyphs); end++ {
+		if glyphs[end].r == '\n' || nbcells+glyphs[end].width > available {
+			break
+		}
+		nbcells += glyphs[end].width
+	}
+	output := str[:glyphs[end].start]
+
+	// and now, check whether a color is active at the truncation point
+	var active bool
+	re := regexp.MustCompile(ansiColorRegex)
+	for _, sequence := range re.FindAllString(output, -1) {
+		active = sequence != ansiReset
+	}
+	if active {
+		return output + ellipsis + ansiReset
+	}
+	return output + ellipsis
+}
+
 // A (physical) line is just a string and they can be justified in various ways
 // according to the alignment parameter: 'l', 'c', 'r', ... To get the desired
 // effect, the contents of the line have to be preceded and continued by a
 // prefix and suffix of white spaces which are returned in the output params
-// prefix and suffix respectively
+// prefix and suffix respectively. Lines in columns aligned on the decimal point
+// ('d') are ragged left as the width of the integer part of other numbers is
+// unknown (see justifyDecimal)
 func justifyLine(line string, alignment rune, width int) (prefix, suffix string) {
 
+	// compute the number of blanks to add to this line. Note that glyphs wider
+	// than the column might exceed it, and then no blank is added
+	blanks := max[int](0, width-countPrintableRuneInString(line))
+
 	// compute the prefix to use for representing this line
 	if unicode.ToLower(rune(alignment)) == 'c' {
-		prefix = strings.Repeat(string(horizontal_blank), (width-countPrintableRuneInString(line))/2)
+		prefix = strings.Repeat(string(horizontal_blank), blanks/2)
 	}
-	if unicode.ToLower(rune(alignment)) == 'r' {
-		prefix = strings.Repeat(string(horizontal_blank), width-countPrintableRuneInString(line))
+	if unicode.ToLower(rune(alignment)) == 'r' || alignment == 'd' {
+		prefix = strings.Repeat(string(horizontal_blank), blanks)
 	}
 
 	// compute the suffix to use for representing the contents of this line
@@ -301,17 +570,58 @@ func justifyLine(line string, alignment rune, width int) (prefix, suffix string)
 		// note that in this case an additional character is added, i.e.,
 		// centered strings are ragged left in case the difference is and odd
 		// number
-		suffix = strings.Repeat(string(horizontal_blank), (width-countPrintableRuneInString(line))/2)
-		suffix += strings.Repeat(" ", (width-countPrintableRuneInString(line))%2)
+		suffix = strings.Repeat(string(horizontal_blank), blanks/2)
+		suffix += strings.Repeat(" ", blanks%2)
 	}
 	if unicode.ToLower(rune(alignment)) == 'l' || alignment == 'p' {
-		suffix = strings.Repeat(string(horizontal_blank), width-countPrintableRuneInString(line))
+		suffix = strings.Repeat(string(horizontal_blank), blanks)
 	}
 
 	// and return the prefix and suffix computed so far
 	return
 }
 
+// return the number of cells taken by the integer part of the given number
+// (including its sign and thousands separators) and by the rest of it (i.e., the
+// decimal point, the fractional part and the exponent). ANSI color codes are
+// ignored. In case the given string is not a number, ok is false
+func splitDecimal(s string) (before, after int, ok bool) {
+
+	re := regexp.MustCompile(decimalRegex)
+	if !re.MatchString(stripANSIColors(s)) {
+		return 0, 0, false
+	}
+
+	// the integer part ends either at the decimal point or the exponent. Note
+	// that none of these characters can appear in an ANSI color code
+	split := strings.IndexAny(s, ".eE")
+	if split < 0 {
+		split = len(s)
+	}
+	return countPrintableRuneInString(s[:split]), countPrintableRuneInString(s[split:]), true
+}
+
+// justify the given line in a column where numbers are aligned on the decimal
+// point, i.e., so that the integer part of all numbers takes the given number of
+// cells (before), and the rest of them takes after cells. All numbers are
+// centered in the given width as a block, and lines which are not numbers are
+// just centered. As in justifyLine, the prefix and suffix of white spaces are
+// returned
+func justifyDecimal(line string, before, after, width int) (prefix, suffix string) {
+
+	b, a, ok := splitDecimal(line)
+	if !ok {
+		return justifyLine(line, 'c', width)
+	}
+
+	// the number of cells before the integer part consists of the offset of
+	// the block of numbers plus the cells required to align the decimal point
+	nbprefix := max[int](0, (width-before-after)/2+before-b)
+	prefix = strings.Repeat(string(horizontal_blank), nbprefix)
+	suffix = strings.Repeat(string(horizontal_blank), max[int](0, width-nbprefix-b-a))
+	return
+}
+
 // return the rune that splits the four regions north-west, north-east,
 // south-west and south-east as stored in the map of splitters with no error. In
 // case that any of the runes given to the west, east, north and south is not
@@ -345,8 +655,8 @@ func getSingleSplitter(west, east, north, south rune) rune {
 }
 
 // return a slice of vertical specifications as a slice of styles. In case the
-// row specification is incorrect, an error is returned and the contents of the
-// result are undetermined
+// row specification is incorrect, a *SpecError is returned and the contents of
+// the result are undetermined
 func getVerticalStyles(rowspec string) ([]style, error) {
 
 	var result []style
@@ -354,12 +664,12 @@ func getVerticalStyles(rowspec string) ([]style, error) {
 	// while the row specification is not empty. Yeah, the row specification
 	// should not consist of runes but just simple ascii characters. Still, we
 	// traverse the string as runes
-	for _, rune := range rowspec {
+	for idx, rune := range rowspec {
 		switch rune {
 		case 't', 'b', 'c':
 			result = append(result, style{alignment: byte(rune)})
 		default:
-			return result, fmt.Errorf("'%v' is an incorrect vertical format", string(rune))
+			return result, newSpecError("row", rowspec, idx, rowSpecifiers)
 		}
 	}
 
@@ -431,50 +741,42 @@ func distributeRows(n int, rows []row) {
 // return the pi-th physical rune which is known to take the li-th logical
 // position. A position is said to be physical if and only if it also takes into
 // account control codes such as ANSI color codes; it is logical otherwise.
+// Logical positions are measured in cells so that wide glyphs take two logical
+// positions.
 //
 // If such position does not exist it returns -1 unless force is True in which
 // case the string is extended to have li logical positions and its physical
-// position is then returned.
+// position is then returned. A logical position which falls in the middle of a
+// wide glyph is considered not to exist and -1 is returned even if force is
+// given.
 //
 // Because the input string might have been modified or not, it returns the
 // resulting string after seeking the physical location of the li-th logical
 // position
 func logicalToPhysical(s string, li int, force bool) (pi int, sout string) {
 
-	// -- initialization: idx is used to count logical runes---i.e., without
+	// -- initialization: idx is used to count logical positions---i.e., without
 	// considering ANSI color codes
 	idx := 0
 
-	// regular expression used to recognize ANSI color codes
-	re := regexp.MustCompile(ansiColorRegex)
-
-	// get the indices to all matches of the regular expression for recognizing
-	// ANSI color codes, and go then over all runes in the given string until
-	// the current logical location goes beyond the logical location requested
-	for colind, colindexes := 0, re.FindAllStringIndex(s, -1); pi < len(s) && idx <= li; {
-
-		// verify if a ANSI color code starts right at this position
-		if colind < len(colindexes) && pi == colindexes[colind][0] {
-
-			// then jump to the first physical location after the regular
-			// expression, and move to the next match of the ANSI color codes
-			pi = colindexes[colind][1]
-			colind++
-		} else {
-
-			// if this is the rune taking the li-th logical position then return
-			// its physical location without modifying the input string
-			if idx == li {
-				return pi, s
-			}
+	// go over all glyphs in the given string until the current logical
+	// location goes beyond the logical location requested
+	for _, g := range getGlyphs(s) {
 
-			// get the rune at the current position
-			_, size := utf8.DecodeRuneInString(s[pi:])
+		// if this is the glyph taking the li-th logical position then return
+		// its physical location without modifying the input string
+		if idx == li && g.width > 0 {
+			return g.start, s
+		}
 
-			// and move forward
-			pi += size
-			idx++
+		// if the li-th logical position falls within this glyph, then it does
+		// not exist
+		if idx < li && li < idx+g.width {
+			return -1, s
 		}
+
+		// and move forward
+		idx += g.width
 	}
 
 	// if we get here is because the given logical location has not been found.
@@ -484,11 +786,11 @@ func logicalToPhysical(s string, li int, force bool) (pi int, sout string) {
 
 		// compute the number of extra spaces that have to be added to the
 		// string
-		diff := 1 + li - countPrintableRuneInString(s)
+		diff := 1 + li - idx
 
 		// and return the physical location of the newly *created* logical
 		// location li along with the new string
-		return pi + diff - 1, s + strings.Repeat(string(horizontal_blank), diff)
+		return len(s) + diff - 1, s + strings.Repeat(string(horizontal_blank), diff)
 	}
 
 	// If force is false, an impossible value is returned as a token to signal
@@ -496,46 +798,27 @@ func logicalToPhysical(s string, li int, force bool) (pi int, sout string) {
 	return -1, s
 }
 
-// return the i-th printable and graphic rune in the given string, if it exists.
-// Otherwise, return an emtpy rune along with an error. It skips color ANSI
-// codes
+// return the rune displayed at the i-th logical position in the given string, if
+// it exists. Otherwise, return an emtpy rune along with an error. It skips color
+// ANSI codes and logical positions are measured in cells so that the base rune
+// of a wide glyph is returned for both of the positions it takes
 func getRune(s string, i int) (rune, error) {
 
-	// -- initialization: idx is used to count physical runes, i.e., the
-	// physical location of each rune considering also the ANSI color codes,
-	// whereas li is used to count logical runes, i.e., those after disregarding
-	// the color ANSI codes
-	idx, li := 0, 0
-
-	// regular expression used to recognize ANSI color codes
-	re := regexp.MustCompile(ansiColorRegex)
-
-	// get the indices to all matches of the regular expression for recognizing
-	// ANSI color codes, and go then over all runes
-	for colind, colindexes := 0, re.FindAllStringIndex(s, -1); idx < len(s); {
-
-		// verify if a ANSI color code starts right at this position
-		if colind < len(colindexes) && idx == colindexes[colind][0] {
-
-			// then jump to the first location after the regular expression, and
-			// move to the next match of the ANSI color codes
-			idx = colindexes[colind][1]
-			colind++
-		} else {
-
-			// get the rune at the current position
-			r, size := utf8.DecodeRuneInString(s[idx:])
+	// -- initialization: li is used to count logical positions, i.e., those
+	// after disregarding the color ANSI codes
+	li := 0
 
-			// if this is the rune taking the i-th logical position then return
-			// it immediately
-			if li == i {
-				return r, nil
-			}
+	// go over all glyphs in the given string
+	for _, g := range getGlyphs(s) {
 
-			// and move forward both physically and logically
-			idx += size
-			li++
+		// if this glyph takes the i-th logical position then return its rune
+		// immediately
+		if li <= i && i < li+g.width {
+			return g.r, nil
 		}
+
+		// and move forward
+		li += g.width
 	}
 
 	// if we exited from the main loop then no rune exists at the specified
@@ -584,7 +867,11 @@ func insertRune(s string, i int, r rune) string {
 // Note that "physical location" is interpreted as follows: i is the i-th slice
 // of the textual representation of the table (so that it is both a physical and
 // logical coordinate); jl is the j-th *rune* printable+graphic non ANSI color
-// code in the string, whereas jl is the j-th *rune* in the string
+// code in the string, whereas jl is the j-th *rune* in the string.
+//
+// Tables are drawn with the UTF-8 box drawing characters and then translated
+// into the runes of their theme, so that splitters are always computed over
+// UTF-8 box drawing characters
 func addSplitter(tab []string, i, jp, jl int) {
 
 	// define variables for storing the runes to the west, east, north and south
@@ -620,82 +907,62 @@ func addSplitter(tab []string, i, jp, jl int) {
 }
 
 // Add splitters to a table that has been already drawn using String () and
-// returns a slice of strings, each representing one line of the table
+// returns a slice of strings, each representing one line of the table. Lines
+// must be drawn with the UTF-8 box drawing characters, as the contents of the
+// cells might contain the runes used by other themes
 func addSplitters(tab []string) {
 
 	// store the physical location of a logical position of any string
 	var pi int
 
-	// -- Initialization: regular expression used to recognize ANSI color codes
-	re := regexp.MustCompile(ansiColorRegex)
-
 	// To do this, the contents of the table are examined (physical) line by
 	// line and all positions adjacent to a vertical separator are processed to
 	// see whether a splitter has to be added there or not
 	for i := 0; i < len(tab); i++ {
 
-		// idx is used to count physical runes, i.e., the physical location of
-		// each rune considering also the ANSI color codes, whereas j is the
-		// logical location of the physical location idx
-		idx, j := 0, 0
+		// j is the logical location of the glyph being examined, i.e., the
+		// number of cells taken by all glyphs before it
+		j := 0
 
-		// make a copy of the i-th line of the table
-		s := tab[i]
+		// go over all glyphs in the i-th line of the table. Note that ANSI color
+		// codes are automatically skipped
+		for _, g := range getGlyphs(tab[i]) {
 
-		// get the indices to all matches of the regular expression for
-		// recognizing ANSI color codes, and go then over all runes in the given
-		// string
-		for colind, colindexes := 0, re.FindAllStringIndex(s, -1); idx < len(s); {
-
-			// verify if a ANSI color code starts right at this position
-			if colind < len(colindexes) && idx == colindexes[colind][0] {
-
-				// then jump to the first location after the regular expression,
-				// and move to the next match of the ANSI color codes
-				idx = colindexes[colind][1]
-				colind++
-			} else {
-
-				// get the rune at the current position
-				r, size := utf8.DecodeRuneInString(s[idx:])
-
-				// now, verify whether this is a vertical separator
-				if isVerticalSeparator(r) {
-
-					// consider adding a splitter above this location in the
-					// physical location (i-1, idx) which maps to the logical
-					// location (i-1, j)
-					if i > 0 {
-
-						pi, tab[i-1] = logicalToPhysical(tab[i-1], j, true)
-						addSplitter(tab, i-1, pi, j)
-					}
-
-					// there will be a lot of times when the following statement is
-					// just repetitive (i.e., it will be anticipating the work that
-					// can be done with the previous if statement when i increases).
-					// However, it is necessary for handling some special cases
-					// where there is no vertical bar beneath the location of the
-					// rune to modify:
-					//                      │<---- A
-					//                    ━━X━━
-					//                      .<---- B
-					//
-					// in this case, only when being located at A it is possible to
-					// substitute the rune at X, whether when being located at B, X
-					// will not be invoked if . is any rune other than a vertical
-					// separator
-					if i <= len(tab)-2 {
-
-						pi, tab[i+1] = logicalToPhysical(tab[i+1], j, true)
-						addSplitter(tab, i+1, pi, j)
-					}
+			// now, verify whether this is a vertical separator
+			if isVerticalSeparator(g.r) {
+
+				// consider adding a splitter above this location in the
+				// physical location (i-1, pi) which maps to the logical
+				// location (i-1, j)
+				if i > 0 {
+
+					pi, tab[i-1] = logicalToPhysical(tab[i-1], j, true)
+					addSplitter(tab, i-1, pi, j)
 				}
 
-				// and move forward
-				idx += size
-				j += 1
+				// there will be a lot of times when the following statement is
+				// just repetitive (i.e., it will be anticipating the work that
+				// can be done with the previous if statement when i increases).
+				// However, it is necessary for handling some special cases
+				// where there is no vertical bar beneath the location of the
+				// rune to modify:
+				//                      │<---- A
+				//                    ━━X━━
+				//                      .<---- B
+				//
+				// in this case, only when being located at A it is possible to
+				// substitute the rune at X, whether when being located at B, X
+				// will not be invoked if . is any rune other than a vertical
+				// separator
+				if i <= len(tab)-2 {
+
+					pi, tab[i+1] = logicalToPhysical(tab[i+1], j, true)
+					addSplitter(tab, i+1, pi, j)
+				}
 			}
+
+			// and move forward
+			j += g.width
 		}
 	}
 }
@@ -378,7 +378,10 @@ func Test_stripLastSeparator(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, got1 := stripLastSeparator(tt.args.spec, tt.args.rexp)
+			got, got1, err := stripLastSeparator(tt.args.spec, tt.args.rexp)
+			if err != nil {
+				t.Fatalf("stripLastSeparator() unexpected error: %v", err)
+			}
 			if got != tt.want {
 				t.Errorf("stripLastSeparator() got = %v, want %v", got, tt.want)
 			}
@@ -572,6 +575,29 @@ func TestSplitParagraph(t *testing.T) {
 				"Y colorín colorado, este",
 				"cuento se ha acabado",
 				""}},
+
+		// wide runes are never split between two lines
+		{args: args{str: "東京タワーは日本の電波塔です",
+			width: 5},
+			want: []string{"東京",
+				"タワ",
+				"ーは",
+				"日本",
+				"の電",
+				"波塔",
+				"です"}},
+
+		{args: args{str: "Tokyo 東京タワー",
+			width: 8},
+			want: []string{"Tokyo",
+				"東京タワ",
+				"ー"}},
+
+		// ANSI color codes take no space
+		{args: args{str: "\033[37;3mEarth is the third\033[0m",
+			width: 10},
+			want: []string{"\033[37;3mEarth is",
+				"the third\033[0m"}},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -582,6 +608,98 @@ func TestSplitParagraph(t *testing.T) {
 	}
 }
 
+func Test_truncateString(t *testing.T) {
+	type args struct {
+		str      string
+		width    int
+		ellipsis string
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+
+		// strings that fit are not modified
+		{args: args{str: "", width: 5, ellipsis: "…"},
+			want: ""},
+
+		{args: args{str: "Gladiator", width: 9, ellipsis: "…"},
+			want: "Gladiator"},
+
+		// strings that exceed the width are truncated with the ellipsis
+		{args: args{str: "Gladiator", width: 8, ellipsis: "…"},
+			want: "Gladiat…"},
+
+		{args: args{str: "Gladiator", width: 8, ellipsis: "..."},
+			want: "Gladi..."},
+
+		{args: args{str: "Gladiator", width: 5, ellipsis: ""},
+			want: "Gladi"},
+
+		// ellipsis that do not fit are not used
+		{args: args{str: "Gladiator", width: 2, ellipsis: "..."},
+			want: "Gl"},
+
+		// strings with several lines are always truncated
+		{args: args{str: "Gladiator\nin arena", width: 20, ellipsis: "…"},
+			want: "Gladiator…"},
+
+		// wide glyphs are never split
+		{args: args{str: "東京タワー", width: 6, ellipsis: "…"},
+			want: "東京…"},
+
+		// ANSI color codes are preserved and reset if needed
+		{args: args{str: "\033[38;2;160;10;10mGladiator\033[0m", width: 8, ellipsis: "…"},
+			want: "\033[38;2;160;10;10mGladiat…\033[0m"},
+
+		{args: args{str: "\033[38;2;160;10;10mGlad\033[0miator", width: 8, ellipsis: "…"},
+			want: "\033[38;2;160;10;10mGlad\033[0miat…"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := truncateString(tt.args.str, tt.args.width, tt.args.ellipsis); got != tt.want {
+				t.Errorf("truncateString() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_splitDecimal(t *testing.T) {
+	tests := []struct {
+		name       string
+		s          string
+		wantBefore int
+		wantAfter  int
+		wantOk     bool
+	}{
+		{s: "42", wantBefore: 2, wantAfter: 0, wantOk: true},
+		{s: "365.256363004", wantBefore: 3, wantAfter: 10, wantOk: true},
+		{s: "-0.0934", wantBefore: 2, wantAfter: 5, wantOk: true},
+		{s: "+.5", wantBefore: 1, wantAfter: 2, wantOk: true},
+		{s: "1,234,567.89", wantBefore: 9, wantAfter: 3, wantOk: true},
+		{s: "6.02e23", wantBefore: 1, wantAfter: 6, wantOk: true},
+		{s: "1E-10", wantBefore: 1, wantAfter: 4, wantOk: true},
+		{s: "\033[38;2;160;10;10m-1.5\033[0m", wantBefore: 2, wantAfter: 2, wantOk: true},
+
+		// strings which are not numbers
+		{s: "", wantOk: false},
+		{s: "n/a", wantOk: false},
+		{s: "1,23", wantOk: false},
+		{s: "1.2.3", wantOk: false},
+		{s: "e10", wantOk: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before, after, ok := splitDecimal(tt.s)
+			if before != tt.wantBefore || after != tt.wantAfter || ok != tt.wantOk {
+				t.Errorf("splitDecimal(%q) = (%v, %v, %v), want (%v, %v, %v)",
+					tt.s, before, after, ok, tt.wantBefore, tt.wantAfter, tt.wantOk)
+			}
+		})
+	}
+}
+
 func Test_countPrintableRuneInString(t *testing.T) {
 	type args struct {
 		s string
@@ -634,6 +752,36 @@ func Test_countPrintableRuneInString(t *testing.T) {
 
 		{args: args{s: "Gladiator in arena consilium capit\033[38;2;160;10;10m\033[0m"},
 			wantCount: 34},
+
+		// East Asian wide and fullwidth runes take two cells
+		{args: args{s: "東京タワー"},
+			wantCount: 10},
+
+		{args: args{s: "\033[38;2;160;10;10m東京\033[0mタワー"},
+			wantCount: 10},
+
+		{args: args{s: "ＡＢＣ abc"},
+			wantCount: 10},
+
+		// combining marks take no space
+		{args: args{s: "cafe\u0301"},
+			wantCount: 4},
+
+		// emojis, possibly joined or with modifiers and variation selectors
+		{args: args{s: "🏯"},
+			wantCount: 2},
+
+		{args: args{s: "👍🏽"},
+			wantCount: 2},
+
+		{args: args{s: "👨\u200d👩\u200d👧"},
+			wantCount: 2},
+
+		{args: args{s: "\u2764\ufe0f"},
+			wantCount: 2},
+
+		{args: args{s: "\u2764"},
+			wantCount: 1},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -673,6 +821,19 @@ func Test_getRune(t *testing.T) {
 			i: 1},
 			want: 'l'},
 
+		// wide runes take two logical positions
+		{args: args{s: "東京│Tokyo",
+			i: 1},
+			want: '東'},
+
+		{args: args{s: "東京│Tokyo",
+			i: 2},
+			want: '京'},
+
+		{args: args{s: "東京│Tokyo",
+			i: 4},
+			want: '│'},
+
 		{args: args{s: "G\033[38;2;160;10;10mladiator in arena consilium capit",
 			i: 1},
 			want: 'l'},
@@ -1045,6 +1206,32 @@ func Test_logicalToPhysical(t *testing.T) {
 			wantPi1:  -1,
 			wantPi2:  57,
 			wantSout: "G\033[38;2;160;10;10mladiator━in arena consilium capit\033[0m "},
+
+		// examples with wide runes. Logical positions in the middle of a wide
+		// rune do not exist
+		{args: args{s: "東京│Tokyo",
+			li: 2},
+			wantPi1:  3,
+			wantPi2:  3,
+			wantSout: "東京│Tokyo"},
+
+		{args: args{s: "東京│Tokyo",
+			li: 3},
+			wantPi1:  -1,
+			wantPi2:  -1,
+			wantSout: "東京│Tokyo"},
+
+		{args: args{s: "東京│Tokyo",
+			li: 4},
+			wantPi1:  6,
+			wantPi2:  6,
+			wantSout: "東京│Tokyo"},
+
+		{args: args{s: "東京│Tokyo",
+			li: 10},
+			wantPi1:  -1,
+			wantPi2:  14,
+			wantSout: "東京│Tokyo "},
 	}
 	for _, tt := range tests {
 
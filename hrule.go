@@ -9,7 +9,7 @@
 package table
 
 import (
-	"log"
+	"fmt"
 	"regexp"
 	"strings"
 	"unicode/utf8"
@@ -120,7 +120,8 @@ func (h hrule) Format(t *Table, irow, jcol int) string {
 	// the width of this column after the splitters
 	rule, ok := t.cells[irow][jcol].(hrule)
 	if !ok {
-		log.Fatalf(" The formatter in location (%v, %v) could not be casted into a rule!", irow, jcol)
+		t.setError(fmt.Errorf("The formatter in location (%v, %v) could not be casted into a rule!", irow, jcol))
+		return string(h)
 	}
 
 	return string(h) + strings.Repeat(string(rule), t.columns[jcol].width)
@@ -0,0 +1,396 @@
+// -*- coding: utf-8 -*-
+// html.go
+// -----------------------------------------------------------------------------
+//
+// Started on <sáb 17-10-2026 01:26:42 (1792200402)>
+//
+
+package table
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// ----------------------------------------------------------------------------
+// HTML
+// ----------------------------------------------------------------------------
+
+// Functions
+// ----------------------------------------------------------------------------
+
+// return the CSS color of the given index in the 256-color palette of ANSI
+// terminals
+func ansi256ToCSS(n int) string {
+
+	// the first 16 colors are the standard and bright colors
+	if n < 16 {
+		return ansiBasicColors[n]
+	}
+
+	// the next 216 colors are a 6x6x6 cube
+	if n < 232 {
+		level := func(v int) int {
+			if v == 0 {
+				return 0
+			}
+			return 55 + 40*v
+		}
+		n -= 16
+		return fmt.Sprintf("rgb(%v,%v,%v)", level(n/36), level((n/6)%6), level(n%6))
+	}
+
+	// and the rest is a grayscale ramp
+	gray := 8 + 10*(n-232)
+	return fmt.Sprintf("rgb(%v,%v,%v)", gray, gray, gray)
+}
+
+// Methods
+// ----------------------------------------------------------------------------
+
+// -- Rendition
+
+// update the rendition with the parameters of the given ANSI color escape
+// sequence. Unknown parameters are ignored
+func (r *rendition) update(sequence string) {
+
+	// get all the numerical parameters of the sequence
+	var params []int
+	for _, param := range strings.FieldsFunc(sequence[2:len(sequence)-1], func(c rune) bool { return c == ';' }) {
+		if n, err := strconv.Atoi(param); err == nil {
+			params = append(params, n)
+		}
+	}
+
+	// and process them all. An empty sequence is equivalent to a reset
+	if len(params) == 0 {
+		params = []int{0}
+	}
+	for idx := 0; idx < len(params); idx++ {
+		switch n := params[idx]; {
+		case n == 0:
+			*r = rendition{}
+		case n == 1:
+			r.bold = true
+		case n == 3:
+			r.italic = true
+		case n == 4:
+			r.underline = true
+		case n == 22:
+			r.bold = false
+		case n == 23:
+			r.italic = false
+		case n == 24:
+			r.underline = false
+		case n >= 30 && n <= 37:
+			r.fg = ansiBasicColors[n-30]
+		case n >= 90 && n <= 97:
+			r.fg = ansiBasicColors[8+n-90]
+		case n >= 40 && n <= 47:
+			r.bg = ansiBasicColors[n-40]
+		case n >= 100 && n <= 107:
+			r.bg = ansiBasicColors[8+n-100]
+		case n == 39:
+			r.fg = ""
+		case n == 49:
+			r.bg = ""
+		case n == 38 || n == 48:
+
+			// extended colors are given either as an index in the 256-color
+			// palette or as RGB components
+			var color string
+			if idx+2 < len(params) && params[idx+1] == 5 {
+				color, idx = ansi256ToCSS(params[idx+2]), idx+2
+			} else if idx+4 < len(params) && params[idx+1] == 2 {
+				color = fmt.Sprintf("rgb(%v,%v,%v)", params[idx+2], params[idx+3], params[idx+4])
+				idx += 4
+			} else {
+				return
+			}
+			if n == 38 {
+				r.fg = color
+			} else {
+				r.bg = color
+			}
+		}
+	}
+}
+
+// return the CSS declarations that correspond to this rendition
+func (r rendition) css() string {
+
+	var declarations []string
+	if r.fg != "" {
+		declarations = append(declarations, "color: "+r.fg)
+	}
+	if r.bg != "" {
+		declarations = append(declarations, "background-color: "+r.bg)
+	}
+	if r.bold {
+		declarations = append(declarations, "font-weight: bold")
+	}
+	if r.italic {
+		declarations = append(declarations, "font-style: italic")
+	}
+	if r.underline {
+		declarations = append(declarations, "text-decoration: underline")
+	}
+	return strings.Join(declarations, "; ")
+}
+
+// return the given text as HTML where all ANSI color escape sequences have been
+// substituted by <span> elements with the corresponding style, and newlines
+// are substituted by <br>. The receiver is the rendition in effect at the
+// beginning of the text and it is updated with the ANSI color escape sequences
+// found in it
+func (r *rendition) toHTML(text string) string {
+
+	// -- initialization: idx is the physical location of the next byte to
+	// process
+	var sb strings.Builder
+	idx := 0
+
+	// write the given text with the current rendition
+	write := func(text string) {
+		if text == "" {
+			return
+		}
+		text = strings.ReplaceAll(html.EscapeString(text), "\n", "<br>")
+		if css := r.css(); css != "" {
+			fmt.Fprintf(&sb, `<span style="%v">%v</span>`, css, text)
+		} else {
+			sb.WriteString(text)
+		}
+	}
+
+	// process all ANSI color escape sequences
+	re := regexp.MustCompile(ansiColorRegex)
+	for _, colindexes := range re.FindAllStringIndex(text, -1) {
+		write(text[idx:colindexes[0]])
+		r.update(text[colindexes[0]:colindexes[1]])
+		idx = colindexes[1]
+	}
+	write(text[idx:])
+
+	return sb.String()
+}
+
+// -- Table
+
+// return the CSS style of the border drawn with the given horizontal rule or
+// vertical separator, or the empty string if none is drawn
+func borderToCSS(r rune) string {
+
+	switch r {
+	case horizontal_single, vertical_single:
+		return "1px solid"
+	case horizontal_double, vertical_double:
+		return "3px double"
+	case horizontal_thick, vertical_thick:
+		return "2px solid"
+	}
+	return ""
+}
+
+// return the CSS style of the border drawn with the given separator, along
+// with the rendition in effect after it. The color of the border is the one in
+// effect when drawing the first vertical separator
+func separatorToCSS(sep string, r rendition) (string, rendition) {
+
+	// -- initialization: idx is used to count physical runes
+	var border string
+	idx := 0
+
+	// regular expression used to recognize ANSI color codes
+	re := regexp.MustCompile(ansiColorRegex)
+
+	// go over all runes of the separator
+	for colind, colindexes := 0, re.FindAllStringIndex(sep, -1); idx < len(sep); {
+
+		// update the rendition with all ANSI color escape sequences
+		if colind < len(colindexes) && idx == colindexes[colind][0] {
+			r.update(sep[colindexes[colind][0]:colindexes[colind][1]])
+			idx = colindexes[colind][1]
+			colind++
+			continue
+		}
+
+		// and annotate the first vertical separator
+		c, size := utf8.DecodeRuneInString(sep[idx:])
+		if border == "" && isVerticalSeparator(c) {
+			border = borderToCSS(c)
+			if r.fg != "" {
+				border += " " + r.fg
+			}
+		}
+		idx += size
+	}
+	return border, r
+}
+
+// return the CSS style of the horizontal border drawn in the given number of
+// columns of the irow-th logical row starting at jcol, if it is a rule, and the
+// empty string otherwise. As cells are given only one border, the first rule
+// drawn in any of these columns is used
+func (t *Table) getHorizontalBorder(irow, jcol, nbcolumns int) string {
+
+	if irow < 0 || irow >= len(t.cells) || !t.isRule(irow) {
+		return ""
+	}
+	for j := jcol; j < jcol+nbcolumns && j < len(t.cells[irow]); j++ {
+		r, _ := utf8.DecodeRuneInString(string(t.cells[irow][j].(hrule)))
+		if border := borderToCSS(r); border != "" {
+			return border
+		}
+	}
+	return ""
+}
+
+// return the HTML representation of the cell in the given location which is
+// known to contain data and not to be taken by a multicell started before
+func (t *Table) cellToHTML(irow, jcol int) string {
+
+	// -- initialization: the attributes and style of the cell, and the format
+	// to use
+	var attrs, styles []string
+	col, text := t.columns[jcol], getCellContents(t.cells[irow][jcol])
+	nbcolumns, nbrows := 1, 1
+
+	// in case this cell is a multicell, then use its format and compute the
+	// number of rows and columns it takes
+	if m, ok := t.cells[irow][jcol].(multicell); ok {
+		col = m.getTable().columns[0]
+		if col.sep == "" {
+			if mprev := getPreviousHorizontalMerger(t, irow, jcol); mprev != nil {
+				col.sep = mprev.getLastVerticalSep()
+			}
+		}
+		nbcolumns, nbrows = m.getNbColumns(), t.getNbDataRows(irow, m.getNbRows())
+		if nbcolumns > 1 {
+			attrs = append(attrs, fmt.Sprintf(`colspan="%v"`, nbcolumns))
+		}
+		if nbrows > 1 {
+			attrs = append(attrs, fmt.Sprintf(`rowspan="%v"`, nbrows))
+		}
+	}
+
+	// alignment
+	switch col.hformat.alignment {
+	case 'c', 'C':
+		styles = append(styles, "text-align: center")
+	case 'r', 'R', 'd':
+		styles = append(styles, "text-align: right")
+	default:
+		styles = append(styles, "text-align: left")
+	}
+	switch col.vformat.alignment {
+	case 'c':
+		styles = append(styles, "vertical-align: middle")
+	case 'b':
+		styles = append(styles, "vertical-align: bottom")
+	default:
+		styles = append(styles, "vertical-align: top")
+	}
+	if col.hformat.isParagraph() {
+		styles = append(styles, fmt.Sprintf("width: %vch", col.hformat.arg))
+	}
+	if col.hformat.isTruncated() {
+		styles = append(styles, fmt.Sprintf("max-width: %vch", col.hformat.arg),
+			"white-space: nowrap", "overflow: hidden", "text-overflow: ellipsis")
+	}
+
+	// borders: the vertical separator before the cell, and also after it if
+	// it reaches the last column
+	border, r := separatorToCSS(col.sep, rendition{})
+	if border != "" {
+		styles = append(styles, "border-left: "+border)
+	}
+	if jcol+nbcolumns >= t.GetNbColumns() {
+		var last string
+		if jcol+nbcolumns < len(t.columns) {
+			last = t.columns[len(t.columns)-1].sep
+		}
+		if m, ok := t.cells[irow][jcol].(multicell); ok {
+			last = m.getLastVerticalSep() + last
+		}
+		if border, _ := separatorToCSS(last, rendition{}); border != "" {
+			styles = append(styles, "border-right: "+border)
+		}
+	}
+
+	// and the horizontal rules immediately above and below the cell
+	if border := t.getHorizontalBorder(irow-1, jcol, nbcolumns); border != "" {
+		styles = append(styles, "border-top: "+border)
+	}
+	iend := irow
+	for nb := 0; iend < len(t.cells) && nb < nbrows; iend++ {
+		if !t.isRule(iend) {
+			nb++
+		}
+	}
+	if border := t.getHorizontalBorder(iend, jcol, nbcolumns); border != "" {
+		styles = append(styles, "border-bottom: "+border)
+	}
+
+	attrs = append(attrs, fmt.Sprintf(`style="%v"`, strings.Join(styles, "; ")))
+	return fmt.Sprintf("<td %v>%v</td>", strings.Join(attrs, " "), r.toHTML(text))
+}
+
+// -- Public
+
+// HTML returns the contents of the table as an HTML table.
+//
+// Every data row is shown as a row of the HTML table, and multicells are shown
+// with the attributes colspan and rowspan. The horizontal and vertical
+// alignment of every cell is given with CSS, and horizontal rules and vertical
+// separators are shown as borders: single, double and thick rules are drawn as
+// solid borders 1px wide, double borders 3px wide and solid borders 2px wide
+// respectively. Because cells are given a single border on every side,
+// multicells spanning several columns are drawn with the first rule found above
+// (or below) any of them.
+//
+// ANSI color escape sequences found in the cells are substituted by <span>
+// elements with the corresponding style. Because ANSI color escape sequences
+// are in effect until they are reset, those found in the vertical separator of
+// a column are also applied to its contents, and the color used to draw a
+// vertical separator is used as the color of the corresponding border
+func (t *Table) HTML() string {
+
+	// hidden columns are exported by removing them from a copy of the table
+	if t.hasHiddenColumns() {
+		return t.getVisibleTable().HTML()
+	}
+
+	output := []string{`<table style="border-collapse: collapse">`}
+	for irow := 0; irow < len(t.cells); irow++ {
+
+		// horizontal rules are drawn as borders of the cells
+		if t.isRule(irow) {
+			continue
+		}
+
+		// draw all cells in this row which are not taken by a multicell that
+		// started before
+		output = append(output, "  <tr>")
+		for j := 0; j < t.GetNbColumns(); j++ {
+			if m := t.getMulticell(irow, j); m != nil &&
+				(m.getRowInit() != irow || m.getColumnInit() != j) {
+				continue
+			}
+			output = append(output, "    "+t.cellToHTML(irow, j))
+		}
+		output = append(output, "  </tr>")
+	}
+	output = append(output, "</table>")
+
+	return strings.Join(output, "\n")
+}
+
+// Local Variables:
+// mode:go
+// fill-column:80
+// End:
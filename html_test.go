@@ -0,0 +1,106 @@
+// -*- coding: utf-8 -*-
+// html_test.go
+// -----------------------------------------------------------------------------
+//
+// Started on <sáb 17-10-2026 01:26:42 (1792200402)>
+//
+
+package table
+
+import "testing"
+
+func Test_rendition_toHTML(t *testing.T) {
+	type args struct {
+		text string
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+
+		// text with no ANSI color escape sequences is just escaped
+		{args: args{text: "a < b\nc"},
+			want: "a &lt; b<br>c"},
+
+		// standard, bright, 256 and RGB colors
+		{args: args{text: "\033[31mred\033[0m"},
+			want: `<span style="color: maroon">red</span>`},
+
+		{args: args{text: "\033[1;92mok\033[22m!"},
+			want: `<span style="color: lime; font-weight: bold">ok</span><span style="color: lime">!</span>`},
+
+		{args: args{text: "\033[38;5;196;48;5;232mx"},
+			want: `<span style="color: rgb(255,0,0); background-color: rgb(8,8,8)">x</span>`},
+
+		{args: args{text: "\033[38;2;160;10;10mGladiator\033[0m in arena"},
+			want: `<span style="color: rgb(160,10,10)">Gladiator</span> in arena`},
+
+		{args: args{text: "\033[3;4mx\033[23;24;39my"},
+			want: `<span style="font-style: italic; text-decoration: underline">x</span>y`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := rendition{}
+			if got := r.toHTML(tt.args.text); got != tt.want {
+				t.Errorf("rendition.toHTML() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTable_HTML(t *testing.T) {
+
+	tab, _ := NewTable("\033[38;2;160;10;10m| c \033[0m|| r |", "c")
+	tab.AddThickRule()
+	tab.AddRow("Name", "\033[1;31mValue\033[0m")
+	tab.AddDoubleRule()
+	tab.AddRow(Multicolumn(2, "| l |", "a\nb"))
+	tab.AddRow(Multirow(2, "c", "M"), 1)
+	tab.AddRow(2)
+	tab.AddSingleRule(0, 1)
+
+	want := `<table style="border-collapse: collapse">
+  <tr>
+    <td style="text-align: center; vertical-align: middle; border-left: 1px solid rgb(160,10,10); border-top: 2px solid; border-bottom: 3px double"><span style="color: rgb(160,10,10)">Name</span></td>
+    <td style="text-align: right; vertical-align: top; border-left: 3px double; border-right: 1px solid; border-top: 2px solid; border-bottom: 3px double"><span style="color: maroon; font-weight: bold">Value</span></td>
+  </tr>
+  <tr>
+    <td colspan="2" style="text-align: left; vertical-align: middle; border-left: 1px solid; border-right: 1px solid; border-top: 3px double">a<br>b</td>
+  </tr>
+  <tr>
+    <td rowspan="2" style="text-align: center; vertical-align: middle; border-left: 1px solid rgb(160,10,10); border-bottom: 1px solid"><span style="color: rgb(160,10,10)">M</span></td>
+    <td style="text-align: right; vertical-align: top; border-left: 3px double; border-right: 1px solid">1</td>
+  </tr>
+  <tr>
+    <td style="text-align: right; vertical-align: top; border-left: 3px double; border-right: 1px solid">2</td>
+  </tr>
+</table>`
+	if got := tab.HTML(); got != want {
+		t.Errorf("Table.HTML() =\n%v\nwant\n%v", got, want)
+	}
+}
+
+func TestTable_HTMLPartialRules(t *testing.T) {
+
+	// partial rules over any column taken by a multicolumn are drawn as its
+	// borders
+	tab, _ := NewTable("l l")
+	tab.AddRow("a", "b")
+	tab.AddSingleRule(1, 2)
+	tab.AddRow(Multicolumn(2, "l", "c"))
+	tab.AddDoubleRule(1, 2)
+
+	want := `<table style="border-collapse: collapse">
+  <tr>
+    <td style="text-align: left; vertical-align: top">a</td>
+    <td style="text-align: left; vertical-align: top; border-bottom: 1px solid">b</td>
+  </tr>
+  <tr>
+    <td colspan="2" style="text-align: left; vertical-align: top; border-top: 1px solid; border-bottom: 3px double">c</td>
+  </tr>
+</table>`
+	if got := tab.HTML(); got != want {
+		t.Errorf("Table.HTML() =\n%v\nwant\n%v", got, want)
+	}
+}
@@ -0,0 +1,306 @@
+// -*- coding: utf-8 -*-
+// latex.go
+// -----------------------------------------------------------------------------
+//
+// Started on <sáb 17-10-2026 01:25:22 (1792200322)>
+//
+
+package table
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// ----------------------------------------------------------------------------
+// LaTeX
+// ----------------------------------------------------------------------------
+
+// Functions
+// ----------------------------------------------------------------------------
+
+// return the given text with all LaTeX special characters escaped and all ANSI
+// color codes removed
+func escapeLaTeX(text string) string {
+
+	var sb strings.Builder
+	for _, r := range stripANSIColors(text) {
+		switch r {
+		case '\\':
+			sb.WriteString(`\textbackslash{}`)
+		case '~':
+			sb.WriteString(`\textasciitilde{}`)
+		case '^':
+			sb.WriteString(`\textasciicircum{}`)
+		case '&', '%', '$', '#', '_', '{', '}':
+			sb.WriteRune('\\')
+			sb.WriteRune(r)
+		default:
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String()
+}
+
+// return the vertical rules in LaTeX that correspond to the given separator.
+// Only vertical separators are considered, and thick vertical separators are
+// drawn as single vertical rules
+func separatorToLaTeX(sep string) (output string) {
+
+	for _, r := range sep {
+		switch r {
+		case vertical_single, vertical_thick:
+			output += "|"
+		case vertical_double:
+			output += "||"
+		}
+	}
+	return
+}
+
+// return the LaTeX column specifier that corresponds to the given style.
+// Paragraphs are given a width in ex units, and those which are not ragged right
+// require the package array
+func styleToLaTeX(s style) string {
+
+	switch s.alignment {
+	case 'p':
+		return fmt.Sprintf("p{%vex}", s.arg)
+	case 'L':
+		return fmt.Sprintf(`>{\raggedright\arraybackslash}p{%vex}`, s.arg)
+	case 'C':
+		return fmt.Sprintf(`>{\centering\arraybackslash}p{%vex}`, s.arg)
+	case 'R':
+		return fmt.Sprintf(`>{\raggedleft\arraybackslash}p{%vex}`, s.arg)
+	case 'd':
+		return "r"
+	}
+	return string(s.alignment)
+}
+
+// return the contents of a cell formatted in LaTeX according to the given
+// style. Lines are separated with \newline in paragraphs, and otherwise they
+// are shown in a nested tabular environment
+func textToLaTeX(text string, s style) string {
+
+	lines := strings.Split(escapeLaTeX(text), "\n")
+	if len(lines) == 1 {
+		return lines[0]
+	}
+	if s.isParagraph() {
+		return strings.Join(lines, `\newline `)
+	}
+	return fmt.Sprintf(`\begin{tabular}[t]{@{}%v@{}}%v\end{tabular}`,
+		string(unicode.ToLower(rune(s.alignment))),
+		strings.Join(lines, `\\`))
+}
+
+// Methods
+// ----------------------------------------------------------------------------
+
+// -- Private
+
+// return the LaTeX command used to draw the horizontal rule in the irow-th
+// logical row of the table. If booktabs is true, the commands of the package
+// booktabs are used
+func (t *Table) ruleToLaTeX(irow int, booktabs bool) string {
+
+	// first, compute the ranges of columns that contain a rule. Every range is
+	// stored as a pair (start, end) with 1-based indices as in LaTeX
+	var ranges [][2]int
+	var rule hrule
+	for j := 0; j < t.GetNbColumns(); j++ {
+		if r := t.cells[irow][j].(hrule); r != hrule(horizontal_blank) {
+			if len(ranges) > 0 && ranges[len(ranges)-1][1] == j {
+				ranges[len(ranges)-1][1]++
+			} else {
+				ranges = append(ranges, [2]int{j + 1, j + 1})
+			}
+			rule = r
+		}
+	}
+
+	// rules which take no column are not shown at all
+	if len(ranges) == 0 {
+		return ""
+	}
+
+	// in case the rule takes the whole table, then draw a full rule
+	var cmds []string
+	if len(ranges) == 1 && ranges[0][0] == 1 && ranges[0][1] == t.GetNbColumns() {
+		if !booktabs {
+			cmds = append(cmds, `\hline`)
+			if rule == hrule(horizontal_double) {
+				cmds = append(cmds, `\hline`)
+			}
+			return strings.Join(cmds, "")
+		}
+
+		// with booktabs, rules above all data are top rules and those below
+		// all data are bottom rules
+		top, bottom := true, true
+		for i := 0; i < len(t.cells); i++ {
+			if !t.isRule(i) {
+				top, bottom = top && i > irow, bottom && i < irow
+			}
+		}
+		switch {
+		case top:
+			return `\toprule`
+		case bottom:
+			return `\bottomrule`
+		case rule == hrule(horizontal_thick):
+			return `\midrule[\heavyrulewidth]`
+		}
+		return `\midrule`
+	}
+
+	// otherwise, draw a partial rule for every range
+	for _, r := range ranges {
+		if booktabs {
+			cmds = append(cmds, fmt.Sprintf(`\cmidrule{%v-%v}`, r[0], r[1]))
+		} else {
+			cmds = append(cmds, fmt.Sprintf(`\cline{%v-%v}`, r[0], r[1]))
+		}
+	}
+	// partial double rules are drawn twice, separated by the same space used
+	// between double full rules, as consecutive \cline commands would be drawn
+	// one on top of the other
+	if !booktabs && rule == hrule(horizontal_double) {
+		return strings.Join(cmds, "") + `\noalign{\vskip\doublerulesep}` + strings.Join(cmds, "")
+	}
+	return strings.Join(cmds, "")
+}
+
+// return the LaTeX column specifier of the given multicell which is shown in
+// the table with the \multicolumn command. The separator before the multicell
+// is given only in case it starts at the first column
+func (t *Table) multicellToLaTeX(m *multicell) (output string) {
+
+	if m.getColumnInit() == 0 {
+		output += separatorToLaTeX(m.getTable().columns[0].sep)
+	}
+	output += styleToLaTeX(m.getTable().columns[0].hformat)
+
+	// the separator after the multicell is the one of the next column if it
+	// exists, and the last separator of the multicell if it reaches the last
+	// column
+	if jnext := m.getColumnInit() + m.getNbColumns(); jnext < len(t.columns) {
+		output += separatorToLaTeX(t.columns[jnext].sep)
+	}
+	if m.getColumnInit()+m.getNbColumns() >= t.GetNbColumns() {
+		output += separatorToLaTeX(m.getLastVerticalSep())
+	}
+	return
+}
+
+// return the number of data rows in the range [iinit, iinit+n)
+func (t *Table) getNbDataRows(iinit, n int) (result int) {
+
+	for irow := iinit; irow < iinit+n && irow < len(t.cells); irow++ {
+		if !t.isRule(irow) {
+			result++
+		}
+	}
+	return
+}
+
+// return the LaTeX representation of the irow-th logical row of the table
+// which is known to contain data
+func (t *Table) rowToLaTeX(irow int) string {
+
+	var cells []string
+	for j := 0; j < t.GetNbColumns(); {
+
+		// in case this location is taken by a multicell
+		if m := t.getMulticell(irow, j); m != nil {
+
+			// the contents of a multicell are shown only in the first row it
+			// takes; multirows require the package multirow
+			var text string
+			if m.getRowInit() == irow {
+				text = textToLaTeX(getCellText(*m), m.getTable().columns[0].hformat)
+				if nbrows := t.getNbDataRows(irow, m.getNbRows()); nbrows > 1 {
+					text = fmt.Sprintf(`\multirow{%v}{*}{%v}`, nbrows, text)
+				}
+			}
+
+			// and multicolumns are shown with \multicolumn even if they are
+			// empty, as they take more than one column. Other multicells are
+			// also shown with \multicolumn as they can modify the format of
+			// the column unless they are multirows
+			if m.getNbColumns() > 1 ||
+				(m.getRowInit() == irow && m.getType() != multirow_t) {
+				text = fmt.Sprintf(`\multicolumn{%v}{%v}{%v}`,
+					m.getNbColumns(), t.multicellToLaTeX(m), text)
+			}
+			cells = append(cells, text)
+			j += m.getNbColumns()
+			continue
+		}
+
+		// otherwise, just show the contents of this cell
+		cells = append(cells, textToLaTeX(getCellText(t.cells[irow][j]), t.columns[j].hformat))
+		j++
+	}
+
+	return strings.Join(cells, " & ") + ` \\`
+}
+
+// -- Public
+
+// LaTeX returns the contents of the table as a LaTeX tabular environment. If
+// booktabs is true, horizontal rules are drawn with the commands of the package
+// booktabs.
+//
+// The column specification is translated into the preamble of the tabular
+// environment: vertical separators are translated into vertical rules (thick
+// vertical separators are drawn as single rules) and any other characters are
+// ignored, whereas the alignment of each column is kept. Paragraphs are given a
+// width in ex units and those which are not ragged right ('C' and 'R') require
+// the package array.
+//
+// Horizontal rules are translated into \hline or \cline (single and thick rules
+// are drawn as single rules, while double rules are drawn twice) or, if
+// booktabs is true, \toprule, \midrule, \bottomrule and \cmidrule.
+//
+// Multicells are translated into \multicolumn and/or \multirow (which requires
+// the package multirow). All LaTeX special characters are escaped and ANSI
+// color codes are removed
+func (t *Table) LaTeX(booktabs bool) string {
+
+	// hidden columns are exported by removing them from a copy of the table
+	if t.hasHiddenColumns() {
+		return t.getVisibleTable().LaTeX(booktabs)
+	}
+
+	// first, compute the preamble of the tabular environment
+	var preamble string
+	for j := 0; j < t.GetNbColumns(); j++ {
+		preamble += separatorToLaTeX(t.columns[j].sep) + styleToLaTeX(t.columns[j].hformat)
+	}
+	if t.GetNbColumns() < len(t.columns) {
+		preamble += separatorToLaTeX(t.columns[len(t.columns)-1].sep)
+	}
+
+	// and now translate every row
+	output := []string{fmt.Sprintf(`\begin{tabular}{%v}`, preamble)}
+	for irow := 0; irow < len(t.cells); irow++ {
+		if t.isRule(irow) {
+			if rule := t.ruleToLaTeX(irow, booktabs); rule != "" {
+				output = append(output, rule)
+			}
+		} else {
+			output = append(output, t.rowToLaTeX(irow))
+		}
+	}
+	output = append(output, `\end{tabular}`)
+
+	return strings.Join(output, "\n")
+}
+
+// Local Variables:
+// mode:go
+// fill-column:80
+// End:
@@ -0,0 +1,106 @@
+// -*- coding: utf-8 -*-
+// latex_test.go
+// -----------------------------------------------------------------------------
+//
+// Started on <sáb 17-10-2026 01:25:22 (1792200322)>
+//
+
+package table
+
+import "testing"
+
+func TestTable_LaTeX(t *testing.T) {
+	tests := []struct {
+		name     string
+		table    func() *Table
+		booktabs bool
+		want     string
+	}{
+
+		// column specifications, rules and escaping
+		{name: "plain",
+			table: func() *Table {
+				t, _ := NewTable("| l || c ||| r | p{10} |")
+				t.AddDoubleRule()
+				t.AddRow("Item", "Qty", "Price", "Notes")
+				t.AddSingleRule()
+				t.AddRow("R&D", 10, "$5", "100% off_x")
+				t.AddSingleRule(1, 3)
+				t.AddRow("Multi\nline", "#1", "{2}", "a\nb")
+				t.AddThickRule()
+				return t
+			},
+			want: `\begin{tabular}{|l||c|r|p{10ex}|}
+\hline\hline
+Item & Qty & Price & Notes \\
+\hline
+R\&D & 10 & \$5 & 100\% off\_x \\
+\cline{2-3}
+\begin{tabular}[t]{@{}l@{}}Multi\\line\end{tabular} & \#1 & \{2\} & a\newline b \\
+\hline
+\end{tabular}`},
+
+		// partial double rules are separated as full double rules
+		{name: "double",
+			table: func() *Table {
+				t, _ := NewTable("l r r")
+				t.AddRow("Region", "Q1", "Q2")
+				t.AddDoubleRule(1, 3)
+				t.AddRow("North", 1, 2)
+				return t
+			},
+			want: `\begin{tabular}{lrr}
+Region & Q1 & Q2 \\
+\cline{2-3}\noalign{\vskip\doublerulesep}\cline{2-3}
+North & 1 & 2 \\
+\end{tabular}`},
+
+		// booktabs
+		{name: "booktabs",
+			table: func() *Table {
+				t, _ := NewTable("l r")
+				t.AddThickRule()
+				t.AddRow("Country", "Population")
+				t.AddSingleRule()
+				t.AddRow("China", "1,394,015,977")
+				t.AddSingleRule(1, 2)
+				t.AddRow("India", "1,326,093,247")
+				t.AddThickRule()
+				return t
+			},
+			booktabs: true,
+			want: `\begin{tabular}{lr}
+\toprule
+Country & Population \\
+\midrule
+China & 1,394,015,977 \\
+\cmidrule{2-2}
+India & 1,326,093,247 \\
+\bottomrule
+\end{tabular}`},
+
+		// multicolumns and multirows
+		{name: "multicells",
+			table: func() *Table {
+				t, _ := NewTable("|c|c|c|")
+				t.AddRow(Multicolumn(2, "|c", "\033[38;2;160;10;10mGroup\033[0m"), "Total")
+				t.AddRow(Multirow(2, "c", "A"), 1, 2)
+				t.AddRow(3, 4)
+				t.AddRow(Multicell(2, 1, "|l", "t", "B"), 5)
+				return t
+			},
+			want: `\begin{tabular}{|c|c|c|}
+\multicolumn{2}{|c|}{Group} & Total \\
+\multirow{2}{*}{A} & 1 & 2 \\
+ & 3 & 4 \\
+\multicolumn{2}{|l|}{B} & 5 \\
+\end{tabular}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.table().LaTeX(tt.booktabs); got != tt.want {
+				t.Errorf("Table.LaTeX() =\n%v\nwant\n%v", got, tt.want)
+			}
+		})
+	}
+}
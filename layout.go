@@ -0,0 +1,314 @@
+// -*- coding: utf-8 -*-
+// layout.go
+// -----------------------------------------------------------------------------
+//
+// Started on <sáb 17-10-2026 01:52:14 (1792201934)>
+//
+
+package table
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ----------------------------------------------------------------------------
+// Table
+// ----------------------------------------------------------------------------
+
+// Methods
+// ----------------------------------------------------------------------------
+
+// -- Private
+
+// return an error if the given column index is out of bounds. If last is true,
+// then the index right after the last column is also accepted
+func (t *Table) verifyColumnIndex(jcol int, last bool) error {
+
+	if jcol < 0 || jcol > t.GetNbColumns() || (jcol == t.GetNbColumns() && !last) {
+		return fmt.Errorf("The column %v does not exist", jcol)
+	}
+	return nil
+}
+
+// return the cell to insert in the given horizontal rule when a new column is
+// inserted right before the jcol-th column. If the rule is drawn both before
+// and after the new column then it is extended, and otherwise it is left blank
+func (t *Table) getInsertedRule(irow, jcol int) formatter {
+
+	switch {
+	case jcol == 0 && t.GetNbColumns() > 0:
+		return t.cells[irow][0]
+	case jcol == t.GetNbColumns() && jcol > 0:
+		return t.cells[irow][jcol-1]
+	case jcol > 0 && t.cells[irow][jcol-1] == t.cells[irow][jcol]:
+		return t.cells[irow][jcol]
+	}
+	return hrule(horizontal_blank)
+}
+
+// insert the given column right before the jcol-th column. Existing rows get
+// an empty cell in the new column, unless they are taken by a multicell which
+// spans over both sides of the new column, which is then enlarged
+func (t *Table) insertColumn(jcol int, col column) {
+
+	// compute first the cells to insert in every row
+	items := make([]formatter, len(t.cells))
+	for i := range t.cells {
+		if t.isRule(i) {
+			items[i] = t.getInsertedRule(i, jcol)
+		} else if m := t.getMulticell(i, jcol); m == nil || m.getColumnInit() == jcol {
+			items[i] = content(horizontal_empty)
+		}
+	}
+
+	// enlarge all multicells which span over both sides of the new column
+	for i := range t.cells {
+		for j := 0; j < jcol; j++ {
+			if m, ok := t.cells[i][j].(multicell); ok && j+m.nbcolumns > jcol {
+				m.nbcolumns++
+				t.cells[i][j] = m
+			}
+		}
+	}
+
+	// and now insert the new column and all cells
+	t.columns = append(t.columns[:jcol], append([]column{col}, t.columns[jcol:]...)...)
+	for i := range t.cells {
+		t.cells[i] = append(t.cells[i][:jcol], append([]formatter{items[i]}, t.cells[i][jcol:]...)...)
+		if jcol < t.rows[i].given {
+			t.rows[i].given++
+		}
+	}
+	t.updateMulticells()
+}
+
+// remove the jcol-th column. Multicells which span over it are shrunk, and
+// those which take only this column are removed as well
+func (t *Table) removeColumn(jcol int) {
+
+	for i := range t.cells {
+
+		// shrink all multicells which start before this column and span over
+		// it
+		for j := 0; j < jcol; j++ {
+			if m, ok := t.cells[i][j].(multicell); ok && j+m.nbcolumns > jcol {
+				m.nbcolumns--
+				t.cells[i][j] = m
+			}
+		}
+
+		// multicells which start in this column and span over the next ones
+		// are moved to the next column
+		if m, ok := t.cells[i][jcol].(multicell); ok && m.nbcolumns > 1 {
+			m.nbcolumns--
+			t.cells[i][jcol+1] = m
+		}
+
+		// and remove the cell in this column
+		t.cells[i] = append(t.cells[i][:jcol], t.cells[i][jcol+1:]...)
+		if jcol < t.rows[i].given {
+			t.rows[i].given--
+		}
+	}
+
+	// the separator of the first column is kept, as it is the left border of
+	// the table
+	if jcol == 0 && len(t.columns) > 1 {
+		t.columns[1].sep = t.columns[0].sep
+	}
+	t.columns = append(t.columns[:jcol], t.columns[jcol+1:]...)
+	t.updateMulticells()
+}
+
+// return true if any column of this table is hidden
+func (t *Table) hasHiddenColumns() bool {
+
+	for _, col := range t.columns {
+		if col.hidden {
+			return true
+		}
+	}
+	return false
+}
+
+// return a copy of this table where hidden columns have been removed. The
+// tables of multicells are copied as well so that the original table is never
+// modified
+func (t *Table) getVisibleTable() *Table {
+
+	result := *t
+	result.columns = append([]column(nil), t.columns...)
+	result.rows = append([]row(nil), t.rows...)
+	result.cells = make([][]formatter, len(t.cells))
+	for i := range t.cells {
+		result.cells[i] = append([]formatter(nil), t.cells[i]...)
+		for j, item := range result.cells[i] {
+			if m, ok := item.(multicell); ok {
+				m.table.columns = append([]column(nil), m.table.columns...)
+				m.table.rows = append([]row(nil), m.table.rows...)
+				result.cells[i][j] = m
+			}
+		}
+	}
+
+	for j := len(result.columns) - 1; j >= 0; j-- {
+		if result.columns[j].hidden {
+			result.removeColumn(j)
+		}
+	}
+	result.recompute()
+	return &result
+}
+
+// -- Public
+
+// InsertColumn inserts a new column right before the jcol-th column with the
+// given specification, which has to contain exactly one column, e.g., "| c" or
+// " p{20}", where the separator is shown before the contents of the column. If
+// jcol is equal to the number of columns, the new column is added after the
+// last one. All rows get an empty cell in the new column, and horizontal rules
+// are extended over the new column if they are drawn on both sides. Multicells
+// which span over both sides of the new column take it as well.
+//
+// It returns an error if the column does not exist or the specification is not
+// correct
+func (t *Table) InsertColumn(jcol int, spec string) error {
+
+	if err := t.verifyColumnIndex(jcol, true); err != nil {
+		return err
+	}
+	columns, err := getColumns(spec)
+	if err != nil {
+		return err
+	}
+	if len(columns) != 1 {
+		return fmt.Errorf("The specification '%v' must contain exactly one column", spec)
+	}
+	separatorToUTF8(&columns[0].sep)
+
+	t.insertColumn(jcol, columns[0])
+	t.recompute()
+	return nil
+}
+
+// AddColumn adds a new column after the last one with the given specification,
+// exactly as InsertColumn does
+func (t *Table) AddColumn(spec string) error {
+	return t.InsertColumn(t.GetNbColumns(), spec)
+}
+
+// RemoveColumn removes the jcol-th column along with its separator, unless it
+// is the first one, in which case the separator of the next column is
+// substituted by it. Multicells which span over this column are shrunk, and
+// those which take only this column are removed.
+//
+// It returns an error if the column does not exist or it is the only one
+func (t *Table) RemoveColumn(jcol int) error {
+
+	if err := t.verifyColumnIndex(jcol, false); err != nil {
+		return err
+	}
+	if t.GetNbColumns() == 1 {
+		return errors.New("The only column of a table can not be removed")
+	}
+
+	t.removeColumn(jcol)
+	t.recompute()
+	return nil
+}
+
+// ReorderColumns moves the columns of the table so that the k-th column is the
+// one given in the k-th position of order, which has to be a permutation of all
+// columns. Columns are moved along with their contents and format, but
+// separators are not moved so that the borders of the table are preserved.
+//
+// It returns an error if order is not a permutation of all columns, or the
+// table contains multicells spanning several columns
+func (t *Table) ReorderColumns(order ...int) error {
+
+	// verify the given order
+	if len(order) != t.GetNbColumns() {
+		return fmt.Errorf("The order must contain exactly %v columns", t.GetNbColumns())
+	}
+	seen := make([]bool, len(order))
+	for _, j := range order {
+		if j < 0 || j >= len(order) || seen[j] {
+			return fmt.Errorf("The order %v is not a permutation of all columns", order)
+		}
+		seen[j] = true
+	}
+	for i := range t.cells {
+		for j := range t.cells[i] {
+			if m, ok := t.cells[i][j].(multicell); ok && m.getNbColumns() > 1 {
+				return fmt.Errorf("The columns can not be reordered because the multicell in location (%v, %v) spans several columns",
+					i, j)
+			}
+		}
+	}
+
+	// move the columns, but not their separators
+	columns := append([]column(nil), t.columns...)
+	for k, j := range order {
+		t.columns[k] = columns[j]
+		t.columns[k].sep = columns[k].sep
+	}
+
+	// and also the cells of all rows
+	for i := range t.cells {
+		cells := append([]formatter(nil), t.cells[i]...)
+		given := 0
+		for k, j := range order {
+			t.cells[i][k] = cells[j]
+			if j < t.rows[i].given {
+				given = k + 1
+			}
+		}
+		if !t.isRule(i) {
+			t.rows[i].given = given
+		}
+	}
+	t.updateMulticells()
+	t.recompute()
+	return nil
+}
+
+// HideColumn hides the jcol-th column, so that it is not shown when the table
+// is drawn or exported, as if it were removed. Hidden columns can be shown
+// again with ShowColumn.
+//
+// It returns an error if the column does not exist or it is the only visible
+// one
+func (t *Table) HideColumn(jcol int) error {
+
+	if err := t.verifyColumnIndex(jcol, false); err != nil {
+		return err
+	}
+	visible := 0
+	for j := 0; j < t.GetNbColumns(); j++ {
+		if !t.columns[j].hidden && j != jcol {
+			visible++
+		}
+	}
+	if visible == 0 {
+		return errors.New("At least one column must be visible")
+	}
+	t.columns[jcol].hidden = true
+	return nil
+}
+
+// ShowColumn shows again the jcol-th column if it was hidden. It returns an
+// error if the column does not exist
+func (t *Table) ShowColumn(jcol int) error {
+
+	if err := t.verifyColumnIndex(jcol, false); err != nil {
+		return err
+	}
+	t.columns[jcol].hidden = false
+	return nil
+}
+
+// Local Variables:
+// mode:go
+// fill-column:80
+// End:
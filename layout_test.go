@@ -0,0 +1,204 @@
+// -*- coding: utf-8 -*-
+// layout_test.go
+// -----------------------------------------------------------------------------
+//
+// Started on <sáb 17-10-2026 01:52:14 (1792201934)>
+//
+
+package table
+
+import "testing"
+
+func TestTable_Layout(t *testing.T) {
+
+	// the following table is modified in every test. Note that multicells can
+	// not be shared among tables, so that rows are created for every test
+	rows := func() [][]any {
+		return [][]any{
+			{"Name", "Value", "Unit"},
+			nil,
+			{"a very long name", 1, "km"},
+			{Multicolumn(2, "| c", "two columns"), "m"},
+			{"b", 2.5},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		edit    func(t *Table) error
+		colspec string
+		want    [][]any
+	}{
+		{name: "add column",
+			edit: func(t *Table) error {
+				if err := t.AddColumn(" || r"); err != nil {
+					return err
+				}
+				return t.SetCell(0, 3, "New")
+			},
+			colspec: "| l | r | c || r |",
+			want: [][]any{
+				{"Name", "Value", "Unit", "New"},
+				nil,
+				{"a very long name", 1, "km"},
+				{Multicolumn(2, "| c", "two columns"), "m"},
+				{"b", 2.5}}},
+		{name: "insert column",
+			edit: func(t *Table) error {
+				if err := t.InsertColumn(1, " | c"); err != nil {
+					return err
+				}
+				return t.SetCell(2, 1, "x")
+			},
+			colspec: "| l | c | r | c |",
+			want: [][]any{
+				{"Name", "", "Value", "Unit"},
+				nil,
+				{"a very long name", "x", 1, "km"},
+				{Multicolumn(3, "| c", "two columns"), "m"},
+				{"b", "", 2.5}}},
+		{name: "insert first column",
+			edit: func(t *Table) error {
+				return t.InsertColumn(0, "| c")
+			},
+			colspec: "| c| l | r | c |",
+			want: [][]any{
+				{"", "Name", "Value", "Unit"},
+				nil,
+				{"", "a very long name", 1, "km"},
+				{"", Multicolumn(2, "| c", "two columns"), "m"},
+				{"", "b", 2.5}}},
+		{name: "remove first column",
+			edit: func(t *Table) error {
+				return t.RemoveColumn(0)
+			},
+			colspec: "| r | c |",
+			want: [][]any{
+				{"Value", "Unit"},
+				nil,
+				{1, "km"},
+				{Multicolumn(1, "| c", "two columns"), "m"},
+				{2.5}}},
+		{name: "remove last column",
+			edit: func(t *Table) error {
+				return t.RemoveColumn(2)
+			},
+			colspec: "| l | r |",
+			want: [][]any{
+				{"Name", "Value"},
+				nil,
+				{"a very long name", 1},
+				{Multicolumn(2, "| c", "two columns")},
+				{"b", 2.5}}},
+		{name: "hide column",
+			edit: func(t *Table) error {
+				return t.HideColumn(1)
+			},
+			colspec: "| l | c |",
+			want: [][]any{
+				{"Name", "Unit"},
+				nil,
+				{"a very long name", "km"},
+				{Multicolumn(1, "| c", "two columns"), "m"},
+				{"b"}}},
+		{name: "show column",
+			edit: func(t *Table) error {
+				if err := t.HideColumn(1); err != nil {
+					return err
+				}
+				_ = t.String()
+				return t.ShowColumn(1)
+			},
+			colspec: "| l | r | c |",
+			want:    rows()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, _ := NewTable("| l | r | c |")
+			addRows(got, rows())
+			_ = got.String()
+			if err := tt.edit(got); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			want, _ := NewTable(tt.colspec)
+			addRows(want, tt.want)
+			if got.String() != want.String() {
+				t.Fatalf("got\n%v\nwant\n%v", got, want)
+			}
+		})
+	}
+}
+
+func TestTable_ReorderColumns(t *testing.T) {
+
+	got, _ := NewTable("| l || r | c |")
+	got.AddRow("Name", "Value", "Unit")
+	got.AddSingleRule(0, 2)
+	got.AddRow("a", 1, Cell{Value: "km", HAlign: 'r'})
+	got.AddRow(Multirow(2, "c", "b"), 2)
+	got.AddRow("", 3)
+	if err := got.ReorderColumns(2, 0, 1); err != nil {
+		t.Fatalf("ReorderColumns() unexpected error: %v", err)
+	}
+
+	want, _ := NewTable("| c || l | r |")
+	want.AddRow("Unit", "Name", "Value")
+	want.AddSingleRule(1, 3)
+	want.AddRow(Cell{Value: "km", HAlign: 'r'}, "a", 1)
+	want.AddRow("", Multirow(2, "c", "b"), 2)
+	want.AddRow(3, "")
+	if got.String() != want.String() {
+		t.Fatalf("got\n%v\nwant\n%v", got, want)
+	}
+}
+
+func TestTable_Layout_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		edit func(t *Table) error
+	}{
+		{name: "insert column out of bounds",
+			edit: func(t *Table) error { return t.InsertColumn(4, "| c") }},
+		{name: "insert incorrect column",
+			edit: func(t *Table) error { return t.InsertColumn(0, "| p") }},
+		{name: "insert several columns",
+			edit: func(t *Table) error { return t.AddColumn("| c | c") }},
+		{name: "remove column out of bounds",
+			edit: func(t *Table) error { return t.RemoveColumn(3) }},
+		{name: "reorder with too few columns",
+			edit: func(t *Table) error { return t.ReorderColumns(1, 0) }},
+		{name: "reorder with repeated columns",
+			edit: func(t *Table) error { return t.ReorderColumns(1, 1, 0) }},
+		{name: "reorder multicolumns",
+			edit: func(t *Table) error { return t.ReorderColumns(2, 1, 0) }},
+		{name: "hide column out of bounds",
+			edit: func(t *Table) error { return t.HideColumn(-1) }},
+		{name: "hide all columns",
+			edit: func(t *Table) error {
+				t.HideColumn(0)
+				t.HideColumn(1)
+				return t.HideColumn(2)
+			}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table, _ := NewTable("| c | c | c |")
+			table.AddRow(Multicolumn(2, "| c", "ab"), "c")
+			if err := tt.edit(table); err == nil {
+				t.Fatalf("expected an error")
+			}
+		})
+	}
+
+	// the only column of a table can not be removed
+	table, _ := NewTable("| c |")
+	if err := table.RemoveColumn(0); err == nil {
+		t.Fatalf("RemoveColumn() expected an error")
+	}
+}
+
+// Local Variables:
+// mode:go
+// fill-column:80
+// End:
@@ -0,0 +1,157 @@
+// -*- coding: utf-8 -*-
+// markdown.go
+// -----------------------------------------------------------------------------
+//
+// Started on <sáb 17-10-2026 01:23:40 (1792200220)>
+//
+
+package table
+
+import (
+	"strings"
+	"unicode"
+)
+
+// ----------------------------------------------------------------------------
+// Markdown
+// ----------------------------------------------------------------------------
+
+// Methods
+// ----------------------------------------------------------------------------
+
+// -- Private
+
+// return the indices of the logical rows of this table which are shown in the
+// header of a Markdown table and those that are shown in its body. The header
+// consists of all data rows above the first horizontal rule that follows any
+// data row, as long as there are more data rows after it. Otherwise, the header
+// consists only of the first data row. Horizontal rules are never returned
+func (t *Table) getMarkdownRows() (header, body []int) {
+
+	// first, compute the data rows of the table, and the index (into the slice
+	// of data rows) of the first one which follows a horizontal rule
+	var rows []int
+	split := -1
+	for irow := 0; irow < len(t.cells); irow++ {
+		if t.isRule(irow) {
+			if split < 0 && len(rows) > 0 {
+				split = len(rows)
+			}
+			continue
+		}
+		rows = append(rows, irow)
+	}
+
+	// in case no rule separates data rows, then the header consists only of
+	// the first one
+	if split < 0 || split >= len(rows) {
+		split = min(1, len(rows))
+	}
+	return rows[:split], rows[split:]
+}
+
+// return the text of the cell in the given location formatted to be shown in a
+// Markdown table: backslashes and pipes are escaped, and newlines are
+// substituted by <br>. Note that backslashes have to be escaped first
+func (t *Table) getMarkdownCell(irow, jcol int) string {
+
+	text := getCellText(t.cells[irow][jcol])
+	text = strings.ReplaceAll(text, "\\", "\\\\")
+	text = strings.ReplaceAll(text, "|", "\\|")
+	return strings.ReplaceAll(text, "\n", "<br>")
+}
+
+// -- Public
+
+// Markdown returns the contents of the table as a GitHub-flavored Markdown
+// table.
+//
+// The header of the Markdown table consists of all data rows above the first
+// horizontal rule (which are then shown in a single row with their contents
+// separated by <br>) or, if there is none, the first data row. Horizontal rules
+// are not shown at all, and the horizontal alignment of each column is taken
+// from the column specification. The contents of all cells are shown without
+// ANSI color codes, backslashes and pipes are escaped and newlines are
+// substituted by <br>.
+//
+// Because Markdown does not support merging cells, the contents of multicells
+// are shown in the first cell they take, the others being left empty.
+//
+// If the table contains no data rows, the empty string is returned
+func (t *Table) Markdown() string {
+
+	// hidden columns are exported by removing them from a copy of the table
+	if t.hasHiddenColumns() {
+		return t.getVisibleTable().Markdown()
+	}
+
+	// get the rows shown in the header and in the body of the table
+	header, body := t.getMarkdownRows()
+	if len(header) == 0 {
+		return ""
+	}
+
+	// compute the contents of all Markdown rows. Note that all header rows
+	// are merged into one
+	rows := make([][]string, 1+len(body))
+	for j := 0; j < t.GetNbColumns(); j++ {
+		var texts []string
+		for _, irow := range header {
+			if text := t.getMarkdownCell(irow, j); text != "" {
+				texts = append(texts, text)
+			}
+		}
+		rows[0] = append(rows[0], strings.Join(texts, "<br>"))
+	}
+	for i, irow := range body {
+		for j := 0; j < t.GetNbColumns(); j++ {
+			rows[1+i] = append(rows[1+i], t.getMarkdownCell(irow, j))
+		}
+	}
+
+	// compute the width of every column so that the Markdown source is also
+	// aligned. Note that the delimiter row requires at least three characters
+	widths := make([]int, t.GetNbColumns())
+	for j := range widths {
+		widths[j] = 3
+		for _, row := range rows {
+			widths[j] = max[int](widths[j], countPrintableRuneInString(row[j]))
+		}
+	}
+
+	// compute now the delimiter row which is inserted after the header
+	var delimiters []string
+	for j, width := range widths {
+		switch unicode.ToLower(rune(t.columns[j].hformat.alignment)) {
+		case 'c':
+			delimiters = append(delimiters, ":"+strings.Repeat("-", width-2)+":")
+		case 'r', 'd':
+			delimiters = append(delimiters, strings.Repeat("-", width-1)+":")
+		default:
+			delimiters = append(delimiters, ":"+strings.Repeat("-", width-1))
+		}
+	}
+
+	// and now draw all rows
+	var output []string
+	for i, row := range rows {
+		var cells []string
+		for j, text := range row {
+			prefix, suffix := justifyLine(text, rune(t.columns[j].hformat.alignment), widths[j])
+			cells = append(cells, prefix+text+suffix)
+		}
+		output = append(output, "| "+strings.Join(cells, " | ")+" |")
+
+		// right after the header add the delimiter row
+		if i == 0 {
+			output = append(output, "| "+strings.Join(delimiters, " | ")+" |")
+		}
+	}
+
+	return strings.Join(output, "\n")
+}
+
+// Local Variables:
+// mode:go
+// fill-column:80
+// End:
@@ -0,0 +1,84 @@
+// -*- coding: utf-8 -*-
+// markdown_test.go
+// -----------------------------------------------------------------------------
+//
+// Started on <sáb 17-10-2026 01:23:40 (1792200220)>
+//
+
+package table
+
+import "testing"
+
+func TestTable_Markdown(t *testing.T) {
+	tests := []struct {
+		name  string
+		table func() *Table
+		want  string
+	}{
+
+		// the empty table
+		{name: "empty",
+			table: func() *Table {
+				t, _ := NewTable("l")
+				t.AddSingleRule()
+				return t
+			},
+			want: ""},
+
+		// with no rules, the first row is the header
+		{name: "no rules",
+			table: func() *Table {
+				t, _ := NewTable("l c r")
+				t.AddRow("Name", "Type", "Size")
+				t.AddRow("go.mod", "file", 104)
+				return t
+			},
+			want: `| Name   | Type | Size |
+| :----- | :--: | ---: |
+| go.mod | file |  104 |`},
+
+		// rows above the first rule are the header, backslashes and pipes
+		// are escaped, newlines are substituted, and ANSI color codes are
+		// removed
+		{name: "header",
+			table: func() *Table {
+				t, _ := NewTable("| p{10} | R{5} |")
+				t.AddThickRule()
+				t.AddRow("Operator", "Total")
+				t.AddRow("", "(units)")
+				t.AddSingleRule()
+				t.AddRow("a|b", "\033[38;2;160;10;10m1\033[0m")
+				t.AddRow("x\ny", 2)
+				t.AddRow("c\\|d", 3)
+				t.AddThickRule()
+				return t
+			},
+			want: `| Operator | Total<br>(units) |
+| :------- | ---------------: |
+| a\|b     |                1 |
+| x<br>y   |                2 |
+| c\\\|d   |                3 |`},
+
+		// multicells are shown in the first cell they take
+		{name: "multicells",
+			table: func() *Table {
+				t, _ := NewTable("|c|c|c|")
+				t.AddRow(Multicolumn(2, "|c", "Group"), "Total")
+				t.AddSingleRule()
+				t.AddRow(Multirow(2, "c", "A"), 1, 2)
+				t.AddRow(3, 4)
+				return t
+			},
+			want: `| Group |     | Total |
+| :---: | :-: | :---: |
+|   A   |  1  |   2   |
+|       |  3  |   4   |`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.table().Markdown(); got != tt.want {
+				t.Errorf("Table.Markdown() =\n%v\nwant\n%v", got, tt.want)
+			}
+		})
+	}
+}
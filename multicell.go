@@ -10,8 +10,10 @@
 package table
 
 import (
+	"errors"
 	"fmt"
 	"strings"
+	"unicode/utf8"
 )
 
 // ----------------------------------------------------------------------------
@@ -37,10 +39,22 @@ import (
 // specification, it is then used as the horizontal rule of the next row.
 func NewMulticell(nbcolumns, nbrows int, cspec, rspec string, args ...any) (multicell, error) {
 
-	// First things first, strip the last separator, if any is given from the
+	// First things first, verify the number of columns and rows
+	if nbcolumns < 1 || nbrows < 1 {
+		return multicell{}, fmt.Errorf("A multicell must span at least one column and one row (%v, %v)",
+			nbcolumns, nbrows)
+	}
+
+	// strip the last separator, if any is given from the
 	// column and row specifications
-	cnewspec, clastsep := stripLastSeparator(cspec, colSpecRegex)
-	rnewspec, rlastsep := stripLastSeparator(rspec, rowSpecRegex)
+	cnewspec, clastsep, err := stripLastSeparator(cspec, colSpecRegex)
+	if err != nil {
+		return multicell{}, err
+	}
+	rnewspec, rlastsep, err := stripLastSeparator(rspec, rowSpecRegex)
+	if err != nil {
+		return multicell{}, err
+	}
 
 	// create a table with the processed column and row specifications
 	t, err := NewTable(cnewspec, rnewspec)
@@ -54,7 +68,9 @@ func NewMulticell(nbcolumns, nbrows int, cspec, rspec string, args ...any) (mult
 	// multicells, arguments are given for the whole table so that it is
 	// necessary now to arrange them by rows
 	for iditem := 0; iditem < len(args); iditem += len(t.columns) {
-		t.AddRow(args[iditem:min(iditem+len(t.columns), len(args))]...)
+		if err := t.AddRow(args[iditem:min(iditem+len(t.columns), len(args))]...); err != nil {
+			return multicell{}, err
+		}
 	}
 
 	// finally, return an instance of a multicell with no error. Note that the
@@ -83,6 +99,9 @@ func NewMulticell(nbcolumns, nbrows int, cspec, rspec string, args ...any) (mult
 // formatted according to the specifications given.
 //
 // This function uses NewMulticell and, if an error is returned, then it panics.
+// It is a convenience wrapper to be used only with specifications known to be
+// correct, e.g., those written in the source code. Otherwise, NewMulticell
+// should be used instead.
 func Multicell(nbcolumns, nbrows int, cspec, rspec string, args ...any) multicell {
 
 	// create a new multicell
@@ -101,13 +120,29 @@ func Multicell(nbcolumns, nbrows int, cspec, rspec string, args ...any) multicel
 
 // Multicolumns are multicells which take only one row whose contents are top
 // aligned by default. In case a different alignment was given in the row
-// specification of the table, the user-defined value will be used instead
-func Multicolumn(nbcolumns int, cspec string, args ...any) multicell {
+// specification of the table, the user-defined value will be used instead.
+//
+// Return a new instance of a multicolumn or an error if it could not be created
+func NewMulticolumn(nbcolumns int, cspec string, args ...any) (multicell, error) {
 
-	m := Multicell(nbcolumns, 1, cspec, "t", args...)
+	m, err := NewMulticell(nbcolumns, 1, cspec, "t", args...)
+	if err != nil {
+		return multicell{}, err
+	}
 
 	// update the type of this multicell to be a multicolumn and return it
 	m.mtype = multicolumn_t
+	return m, nil
+}
+
+// Return a new instance of a multicolumn. This function uses NewMulticolumn
+// and, if an error is returned, then it panics
+func Multicolumn(nbcolumns int, cspec string, args ...any) multicell {
+
+	m, err := NewMulticolumn(nbcolumns, cspec, args...)
+	if err != nil {
+		panic(err)
+	}
 	return m
 }
 
@@ -117,13 +152,29 @@ func Multicolumn(nbcolumns int, cspec string, args ...any) multicell {
 // instead.
 //
 // In contraposition, multirows only contain one logical row and thus,
-// only one arg can be given
-func Multirow(nbrows int, rspec string, arg any) multicell {
+// only one arg can be given.
+//
+// Return a new instance of a multirow or an error if it could not be created
+func NewMultirow(nbrows int, rspec string, arg any) (multicell, error) {
 
-	m := Multicell(1, nbrows, "c", rspec, arg)
+	m, err := NewMulticell(1, nbrows, "c", rspec, arg)
+	if err != nil {
+		return multicell{}, err
+	}
 
 	// update the type of this multicell to be a multirow and return it
 	m.mtype = multirow_t
+	return m, nil
+}
+
+// Return a new instance of a multirow. This function uses NewMultirow and, if
+// an error is returned, then it panics
+func Multirow(nbrows int, rspec string, arg any) multicell {
+
+	m, err := NewMultirow(nbrows, rspec, arg)
+	if err != nil {
+		panic(err)
+	}
 	return m
 }
 
@@ -174,10 +225,11 @@ func (m multicell) Process(t *Table, irow, jcol int) []formatter {
 
 			// redo the table using as first separator the one provided in the
 			// previous multicell. In case of error (which is unlikely as we are
-			// only adding the separator found in the previous multicell) a
-			// panic is generated, there's not much we could do at this stage
+			// only adding the separator found in the previous multicell) it is
+			// recorded in the table and the multicell is drawn with its own
+			// table
 			if tm, err := NewTable(mprev.getLastVerticalSep() + m.cspec); err != nil {
-				panic(err)
+				t.setError(err)
 			} else {
 
 				// the following is ugly ... I know :( and it is a little bit of
@@ -194,9 +246,30 @@ func (m multicell) Process(t *Table, irow, jcol int) []formatter {
 		}
 	}
 
+	// the inner table is drawn with the same ellipsis than the table, and with
+	// the UTF-8 box drawing characters, as it is translated into the theme of
+	// the table along with the rest of its lines
+	m.table.theme, m.table.ellipsis = ThemeUTF8, t.ellipsis
+
+	// draw the inner table recording any error found in the table
+	output, err := m.table.Render()
+	if err != nil {
+		t.setError(err)
+	}
+
 	// store all lines as different multicells where only the output of each
-	// line is stored separately
-	for _, line := range strings.Split(fmt.Sprintf("%v", m.table), "\n") {
+	// line is stored separately. Lines drawn in horizontal rules of the table
+	// draw the rule of the previous column before their first vertical
+	// separator, as horizontal rules do
+	row, offset := irow, 0
+	for idx, line := range strings.Split(output, "\n") {
+		for row < irow+m.nbrows-1 && row < len(t.rows) && idx >= offset+t.rows[row].height {
+			offset += t.rows[row].height
+			row++
+		}
+		if row < len(t.rows) && t.isRule(row) && jcol > 0 {
+			line = m.getRuleLine(string(t.cells[row][jcol-1].(hrule)), line)
+		}
 
 		// note that only each line is computed separately. In addition,
 		// other information is passed to the multicell to be formatted
@@ -231,6 +304,38 @@ func (m multicell) Format(t *Table, irow, jcol int) string {
 	return m.output + m.clastsep
 }
 
+// return the given line of this multicell where all blanks of its first
+// separator before any vertical separator are substituted by the given
+// horizontal rule
+func (m multicell) getRuleLine(rule, line string) string {
+
+	brkrule, _ := utf8.DecodeRuneInString(rule)
+	sep := m.table.columns[0].sep
+	if rule == "" || brkrule == ' ' || !strings.HasPrefix(line, sep) {
+		return line
+	}
+	prefix := []rune(sep)
+	for idx, irune := range prefix {
+		if isVerticalSeparator(irune) {
+			break
+		}
+		if irune == ' ' {
+			prefix[idx] = brkrule
+		}
+	}
+	return string(prefix) + line[len(sep):]
+}
+
+// return an error if this multicell can not be inserted in a table, e.g.,
+// because it was not created with NewMulticell
+func (m multicell) verify() error {
+
+	if len(m.table.columns) == 0 || m.nbcolumns < 1 || m.nbrows < 1 {
+		return errors.New("Invalid multicell. Multicells must be created with NewMulticell, NewMulticolumn or NewMultirow")
+	}
+	return nil
+}
+
 // Public services to access the contents of a multicell
 func (m multicell) getType() multicellType {
 	return m.mtype
@@ -0,0 +1,111 @@
+// -*- coding: utf-8 -*-
+// multicell_test.go
+// -----------------------------------------------------------------------------
+//
+// Started on <sáb 17-10-2026 01:45:29 (1792201529)>
+//
+
+package table
+
+import "testing"
+
+func TestNewMulticell_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		new  func() (multicell, error)
+	}{
+		{name: "no columns",
+			new: func() (multicell, error) { return NewMulticell(0, 1, "c", "t", "a") }},
+		{name: "no rows",
+			new: func() (multicell, error) { return NewMulticell(1, 0, "c", "t", "a") }},
+		{name: "column specification",
+			new: func() (multicell, error) { return NewMulticell(2, 1, "| p |", "t", "a") }},
+		{name: "row specification",
+			new: func() (multicell, error) { return NewMulticell(2, 1, "| c |", "xt", "a") }},
+		{name: "multicolumn",
+			new: func() (multicell, error) { return NewMulticolumn(2, "c | L{0} |", "a", "b") }},
+		{name: "multirow",
+			new: func() (multicell, error) { return NewMultirow(2, "tt", "a") }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.new(); err == nil {
+				t.Fatalf("expected an error")
+			}
+		})
+	}
+}
+
+func TestTable_Render(t *testing.T) {
+	tests := []struct {
+		name    string
+		build   func(t *Table) error
+		want    string
+		wantErr bool
+	}{
+		{name: "correct table",
+			build: func(t *Table) error {
+				m, err := NewMulticolumn(2, "│ c │", "Header")
+				if err != nil {
+					return err
+				}
+				if err := t.AddRow(m); err != nil {
+					return err
+				}
+				return t.AddRow("a", "b")
+			},
+			want: `│ Header │
+│ a  │ b │`},
+		{name: "multirow beyond the last row",
+			build: func(t *Table) error {
+				m, err := NewMultirow(3, "c", "a")
+				if err != nil {
+					return err
+				}
+				return t.AddRow(m, "b")
+			},
+			wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table, err := NewTable("│ c │ c │")
+			if err != nil {
+				t.Fatalf("NewTable() unexpected error: %v", err)
+			}
+			if err := tt.build(table); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			got, err := table.Render()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Render() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Fatalf("Render() = %q, want %q", got, tt.want)
+			}
+			if table.String() != got {
+				t.Fatalf("String() = %q, want %q", table.String(), got)
+			}
+		})
+	}
+}
+
+func TestTable_AddRow_InvalidMulticell(t *testing.T) {
+	table, err := NewTable("│ c │ c │")
+	if err != nil {
+		t.Fatalf("NewTable() unexpected error: %v", err)
+	}
+
+	// multicells which could not be created are rejected
+	m, _ := NewMulticell(0, 1, "c", "t")
+	if err := table.AddRow(m); err == nil {
+		t.Fatalf("AddRow() expected an error")
+	}
+	if table.GetNbRows() != 0 {
+		t.Fatalf("AddRow() added a row with an invalid multicell")
+	}
+}
+
+// Local Variables:
+// mode:go
+// fill-column:80
+// End:
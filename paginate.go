@@ -0,0 +1,200 @@
+// -*- coding: utf-8 -*-
+// paginate.go
+// -----------------------------------------------------------------------------
+//
+// Started on <sáb 17-10-2026 02:08:17 (1792202897)>
+//
+
+package table
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ----------------------------------------------------------------------------
+// Paginate
+// ----------------------------------------------------------------------------
+
+// Methods
+// ----------------------------------------------------------------------------
+
+// -- Private
+
+// return a page with the given lines of the header and the body closed with
+// the given bottom rule. Splitters are computed over the lines of the page
+// only, so that they are consistent with the lines above and below them, and
+// then all lines are drawn with the runes of the theme of the table
+func (t *Table) getPage(header, body []string, bottom string) string {
+
+	page := append(append(append([]string(nil), header...), body...), bottom)
+	page = t.theme.normalizeLines(page)
+	addSplitters(page)
+	return strings.Join(t.theme.translateLines(page), "\n")
+}
+
+// return the first row after the header of this table, which consists of all
+// horizontal rules at the top of the table, the given number of data rows, and
+// the horizontal rules right after them
+func (t *Table) getHeaderEnd(nbheaders int) (int, error) {
+
+	if nbheaders < 0 {
+		return 0, errors.New("The number of header rows can not be negative")
+	}
+	i := 0
+	for ; i < len(t.rows) && t.isRule(i); i++ {
+	}
+	for n := 0; n < nbheaders; n++ {
+		if i >= len(t.rows) {
+			return 0, fmt.Errorf("The table has less than %v data rows to repeat as headers", nbheaders)
+		}
+		for i++; i < len(t.rows) && t.isRule(i); i++ {
+		}
+	}
+	if i < len(t.rows) && t.verifyMultirows(i) != nil {
+		return 0, errors.New("The header rows can not be separated from the rest of the table because a multicell spans them")
+	}
+	return i, nil
+}
+
+// return the blocks of consecutive rows in the given range which can not be
+// separated because there is a multicell spanning several of them. Every
+// block is given with its first row and the row after its last one
+func (t *Table) getBlocks(init, end int) (blocks [][2]int) {
+
+	for i := init; i < end; {
+		k := i + 1
+		for k < end && t.verifyMultirows(k) != nil {
+			k++
+		}
+		blocks = append(blocks, [2]int{i, k})
+		i = k
+	}
+	return
+}
+
+// -- Public
+
+// Paginate splits the physical lines of the table into pages with at most the
+// given number of lines each. The header of the table, which consists of all
+// horizontal rules at the top of the table, the given number of data rows and
+// the horizontal rules right after them, is repeated at the top of every
+// page. Tables are split only between rows which are not spanned by the same
+// multirow, so that neither rows with several lines nor multirows are ever
+// cut, and the horizontal rules found where a page is split are dropped.
+//
+// Every page is closed with a bottom rule, which is the last row of the table
+// if it is a horizontal rule, or a single rule drawn over all columns
+// otherwise. The splitters of every page are computed over its own lines, so
+// that the bottom rule is properly joined with the vertical separators above
+// it. Hidden columns are not shown, and tables with no data rows have no
+// pages.
+//
+// It returns an error if the table can not be drawn, the number of lines of
+// every page is not strictly positive, the number of header rows is negative
+// or larger than the number of data rows, a multirow spans the header and the
+// rows after it, or the header along with any block of rows which can not be
+// split and the bottom rule do not fit in a single page
+func (t Table) Paginate(nblines, nbheaders int) ([]string, error) {
+
+	// first, verify that the table can be drawn at all and that pages have
+	// room for some lines
+	if err := t.verify(); err != nil {
+		return nil, err
+	}
+	if nblines <= 0 {
+		return nil, fmt.Errorf("Pages can not have %v lines", nblines)
+	}
+
+	// tables with no data rows have no pages
+	i := 0
+	for ; i < len(t.rows) && t.isRule(i); i++ {
+	}
+	if i == len(t.rows) {
+		return nil, nil
+	}
+
+	// hidden columns are drawn by removing them from a copy of the table
+	if t.hasHiddenColumns() {
+		return t.getVisibleTable().Paginate(nblines, nbheaders)
+	}
+	end, err := t.getHeaderEnd(nbheaders)
+	if err != nil {
+		return nil, err
+	}
+
+	// pages are closed with the last row of the table if it is a horizontal
+	// rule. Otherwise, a single rule is added to a copy of the table so that
+	// it is drawn with the width of all columns
+	if !t.isRule(len(t.rows) - 1) {
+		t.cells = append([][]formatter(nil), t.cells...)
+		t.rows = append([]row(nil), t.rows...)
+		if err := t.addRule(hrule(horizontal_single)); err != nil {
+			return nil, err
+		}
+	}
+	last := len(t.rows) - 1
+
+	// draw all physical lines with no splitters, and compute the first one of
+	// every logical row
+	lines, err := t.render()
+	if err != nil {
+		return nil, err
+	}
+	start := make([]int, len(t.rows)+1)
+	for i, row := range t.rows {
+		start[i+1] = start[i] + row.height
+	}
+	header, bottom := lines[:start[end]], lines[start[last]]
+
+	// tables with no data after the header are drawn in a single page
+	blocks := t.getBlocks(end, last)
+	data := false
+	for _, block := range blocks {
+		data = data || !t.isRule(block[0]) || block[1] > block[0]+1
+	}
+	if !data {
+		if len(lines) > nblines {
+			return nil, fmt.Errorf("The table does not fit in pages of %v lines", nblines)
+		}
+		return []string{t.getPage(nil, lines[:start[last]], bottom)}, nil
+	}
+
+	// add as many blocks of rows as possible to every page. Horizontal rules
+	// are added only when they are followed by more data in the same page
+	var pages []string
+	var body, pending []string
+	for b := 0; b < len(blocks); {
+		init, next := blocks[b][0], blocks[b][1]
+		block := lines[start[init]:start[next]]
+		if t.isRule(init) && next == init+1 {
+			if len(body) > 0 {
+				pending = append(pending, block...)
+			}
+			b++
+			continue
+		}
+		if len(header)+len(body)+len(pending)+len(block)+1 <= nblines {
+			body = append(append(body, pending...), block...)
+			pending = nil
+			b++
+			continue
+		}
+
+		// if this block does not fit in an empty page, then the table can not
+		// be paginated at all
+		if len(body) == 0 {
+			return nil, fmt.Errorf("The rows %v to %v along with the header and the bottom rule do not fit in pages of %v lines",
+				init, next-1, nblines)
+		}
+		pages = append(pages, t.getPage(header, body, bottom))
+		body, pending = nil, nil
+	}
+	return append(pages, t.getPage(header, body, bottom)), nil
+}
+
+// Local Variables:
+// mode:go
+// fill-column:80
+// End:
@@ -0,0 +1,168 @@
+// -*- coding: utf-8 -*-
+// paginate_test.go
+// -----------------------------------------------------------------------------
+//
+// Started on <sáb 17-10-2026 02:08:17 (1792202897)>
+//
+
+package table
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTable_Paginate(t *testing.T) {
+
+	tests := []struct {
+		name      string
+		nblines   int
+		nbheaders int
+		want      []string
+		wantErr   bool
+	}{
+		{name: "pages", nblines: 8, nbheaders: 1,
+			want: []string{`┍━━━━━━━┯━━━━━━━┑
+│ Name  │ Units │
+╞═══════╪═══════╡
+│ tea   │     1 │
+│ cake  │     2 │
+┕━━━━━━━┷━━━━━━━┙`, `┍━━━━━━━┯━━━━━━━┑
+│ Name  │ Units │
+╞═══════╪═══════╡
+│ milk  │     3 │
+│       │     4 │
+┕━━━━━━━┷━━━━━━━┙`, `┍━━━━━━━┯━━━━━━━┑
+│ Name  │ Units │
+╞═══════╪═══════╡
+│ bread │     5 │
+│       │     6 │
+┕━━━━━━━┷━━━━━━━┙`}},
+		{name: "rules", nblines: 10, nbheaders: 1,
+			want: []string{`┍━━━━━━━┯━━━━━━━┑
+│ Name  │ Units │
+╞═══════╪═══════╡
+│ tea   │     1 │
+│ cake  │     2 │
+├───────┼───────┤
+│ milk  │     3 │
+│       │     4 │
+┕━━━━━━━┷━━━━━━━┙`, `┍━━━━━━━┯━━━━━━━┑
+│ Name  │ Units │
+╞═══════╪═══════╡
+│ bread │     5 │
+│       │     6 │
+┕━━━━━━━┷━━━━━━━┙`}},
+		{name: "no-headers", nblines: 6, nbheaders: 0,
+			want: []string{`┍━━━━━━━┯━━━━━━━┑
+│ Name  │ Units │
+╞═══════╪═══════╡
+│ tea   │     1 │
+│ cake  │     2 │
+┕━━━━━━━┷━━━━━━━┙`, `┍━━━━━━━┯━━━━━━━┑
+│ milk  │     3 │
+│       │     4 │
+┕━━━━━━━┷━━━━━━━┙`, `┍━━━━━━━┯━━━━━━━┑
+│ bread │     5 │
+│       │     6 │
+┕━━━━━━━┷━━━━━━━┙`}},
+		{name: "single", nblines: 12, nbheaders: 1,
+			want: []string{`┍━━━━━━━┯━━━━━━━┑
+│ Name  │ Units │
+╞═══════╪═══════╡
+│ tea   │     1 │
+│ cake  │     2 │
+├───────┼───────┤
+│ milk  │     3 │
+│       │     4 │
+├───────┼───────┤
+│ bread │     5 │
+│       │     6 │
+┕━━━━━━━┷━━━━━━━┙`}},
+		{name: "small", nblines: 5, nbheaders: 1, wantErr: true},
+		{name: "headers", nblines: 8, nbheaders: 6, wantErr: true},
+		{name: "negative", nblines: 8, nbheaders: -1, wantErr: true},
+		{name: "null", nblines: 0, nbheaders: 0, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table, _ := NewTable("│ l │ r │")
+			table.AddThickRule()
+			table.AddRow("Name", "Units")
+			table.AddDoubleRule()
+			table.AddRow("tea", 1)
+			table.AddRow("cake", 2)
+			table.AddSingleRule()
+			table.AddRow(Multirow(2, "c", "milk"), 3)
+			table.AddRow(4)
+			table.AddSingleRule()
+			table.AddRow("bread", "5\n6")
+			table.AddThickRule()
+
+			got, err := table.Paginate(tt.nblines, tt.nbheaders)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Paginate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Paginate() =\n%v\nwant\n%v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTable_PaginateEmpty(t *testing.T) {
+
+	// tables with no data rows have no pages
+	table, _ := NewTable("│ l │ r │")
+	if got, err := table.Paginate(8, 0); err != nil || got != nil {
+		t.Errorf("Paginate() = %v, %v, want no pages", got, err)
+	}
+	table.AddSingleRule()
+	table.AddSingleRule()
+	if got, err := table.Paginate(8, 0); err != nil || got != nil {
+		t.Errorf("Paginate() = %v, %v, want no pages", got, err)
+	}
+}
+
+func TestTable_PaginateGroups(t *testing.T) {
+
+	table, _ := NewTable("│ l │ l │ r │")
+	table.AddRow("Region", "City", "Sales")
+	table.AddSingleRule()
+	table.AddGroups([][]any{
+		{"North", "Oslo", 10},
+		{"North", "Bergen", 4},
+		{"South", "Rome", 7},
+		{"South", "Naples", 2},
+		{"South", "Bari", 1}}, Group{Column: 0, Rule: true})
+
+	// the second group does not fit in the first page, and the rule before it
+	// is replaced with the bottom rule
+	want := []string{`│ Region │ City   │ Sales │
+├────────┼────────┼───────┤
+│ North  │ Oslo   │    10 │
+│        │ Bergen │     4 │
+└────────┴────────┴───────┘`, `│ Region │ City   │ Sales │
+├────────┼────────┼───────┤
+│        │ Rome   │     7 │
+│ South  │ Naples │     2 │
+│        │ Bari   │     1 │
+└────────┴────────┴───────┘`}
+	got, err := table.Paginate(8, 1)
+	if err != nil {
+		t.Fatalf("Paginate() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Paginate() =\n%v\nwant\n%v", got, want)
+	}
+
+	// and the table is not modified
+	if table.GetNbRows() != 8 {
+		t.Errorf("Paginate() modified the table")
+	}
+}
+
+// Local Variables:
+// mode:go
+// fill-column:80
+// End:
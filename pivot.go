@@ -0,0 +1,300 @@
+// -*- coding: utf-8 -*-
+// pivot.go
+// -----------------------------------------------------------------------------
+//
+// Started on <sáb 17-10-2026 02:01:59 (1792202519)>
+//
+
+package table
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ----------------------------------------------------------------------------
+// Pivot
+// ----------------------------------------------------------------------------
+
+// Functions
+// ----------------------------------------------------------------------------
+
+// -- Private
+
+// return the string used to identify the given keys of a row and a column.
+// Keys are compared with the strings that represent them
+func getPivotKey(rows, columns []any) string {
+
+	var keys []string
+	for _, key := range rows {
+		keys = append(keys, getGroupKey(key))
+	}
+	keys = append(keys, "")
+	for _, key := range columns {
+		keys = append(keys, getGroupKey(key))
+	}
+	return strings.Join(keys, "\x00")
+}
+
+// Methods
+// ----------------------------------------------------------------------------
+
+// -- Private
+
+// insert the given keys in the tree rooted at this node, unless they already
+// exist
+func (n *pivotNode) insert(keys []any) {
+
+	if len(keys) == 0 {
+		return
+	}
+	for _, child := range n.children {
+		if getGroupKey(child.key) == getGroupKey(keys[0]) {
+			child.insert(keys[1:])
+			return
+		}
+	}
+	child := &pivotNode{key: keys[0]}
+	child.insert(keys[1:])
+	n.children = append(n.children, child)
+}
+
+// return the number of leaves of the tree rooted at this node
+func (n *pivotNode) getNbLeaves() int {
+
+	if len(n.children) == 0 {
+		return 1
+	}
+	var result int
+	for _, child := range n.children {
+		result += child.getNbLeaves()
+	}
+	return result
+}
+
+// return the keys of all leaves of the tree rooted at this node, each one
+// preceded by the given prefix
+func (n *pivotNode) getLeaves(prefix []any) (result [][]any) {
+
+	for _, child := range n.children {
+		keys := append(append([]any(nil), prefix...), child.key)
+		if len(child.children) == 0 {
+			result = append(result, keys)
+		} else {
+			result = append(result, child.getLeaves(keys)...)
+		}
+	}
+	return
+}
+
+// return all the nodes found at the given depth of the tree rooted at this
+// node, where its children are at depth 0
+func (n *pivotNode) getLevel(level int) (result []*pivotNode) {
+
+	for _, child := range n.children {
+		if level == 0 {
+			result = append(result, child)
+		} else {
+			result = append(result, child.getLevel(level-1)...)
+		}
+	}
+	return
+}
+
+// return an error if the given records can not be shown in a pivot table
+func (p Pivot) verify(records []PivotRecord) error {
+
+	if len(records) == 0 {
+		return errors.New("At least one record must be given to build a pivot table")
+	}
+	if p.Reduce == nil && (p.Function < AggregateSum || p.Function > AggregateCount) {
+		return fmt.Errorf("Unknown aggregate function %v", p.Function)
+	}
+	nbrows, nbcolumns := len(records[0].Rows), len(records[0].Columns)
+	if nbrows == 0 || nbcolumns == 0 {
+		return errors.New("The records of a pivot table must have at least one key for their row and column")
+	}
+	for i, record := range records {
+		if len(record.Rows) != nbrows || len(record.Columns) != nbcolumns {
+			return fmt.Errorf("The record %v has %v keys for its row and %v for its column but %v and %v were expected",
+				i, len(record.Rows), len(record.Columns), nbrows, nbcolumns)
+		}
+	}
+	if len(p.Titles) > nbrows {
+		return fmt.Errorf("%v titles were given but the rows have only %v keys", len(p.Titles), nbrows)
+	}
+	return nil
+}
+
+// return the aggregate of the given values
+func (p Pivot) reduce(values []float64) any {
+
+	if p.Reduce != nil {
+		return p.Reduce(values)
+	}
+	return reduce(p.Function, values)
+}
+
+// -- Public
+
+// NewPivotTable creates a new table with the aggregates of the values of the
+// given records in long format, as described in the pivot. The table has one
+// column for every key of the rows, and one column for every combination of
+// keys of the columns, which are shown under hierarchical headers made of
+// multicolumns with partial rules under every level. Rows and columns are
+// shown in the order in which their keys appear first in the records, and
+// consecutive rows with the same keys are collapsed into multirows as in
+// AddGroups. Only values of any numerical type are aggregated.
+//
+// Totals are computed over the values of all the records of every row and
+// column, rather than over their aggregates. The row with the totals of every
+// column is added as a footer, so that more rows can be added to the table
+// later with their own footers.
+//
+// It returns an error if no record is given, the records do not have at least
+// one key for their rows and columns or they have a different number of keys,
+// there are more titles than keys of the rows, or the aggregate function is
+// unknown
+func NewPivotTable(pivot Pivot, records []PivotRecord) (*Table, error) {
+
+	if err := pivot.verify(records); err != nil {
+		return &Table{}, err
+	}
+	nbrows, nbcolumns := len(records[0].Rows), len(records[0].Columns)
+
+	// arrange the keys of rows and columns in trees, and collect all the
+	// numerical values of every combination of keys, row and column
+	var rowTree, columnTree pivotNode
+	found := make(map[string]bool)
+	values := make(map[string][]float64)
+	rowValues, columnValues := make(map[string][]float64), make(map[string][]float64)
+	var all []float64
+	for _, record := range records {
+		rowTree.insert(record.Rows)
+		columnTree.insert(record.Columns)
+
+		key := getPivotKey(record.Rows, record.Columns)
+		rkey, ckey := getPivotKey(record.Rows, nil), getPivotKey(nil, record.Columns)
+		found[key] = true
+		if value, ok := toNumber(getValue(record.Value)); ok {
+			values[key] = append(values[key], value)
+			rowValues[rkey] = append(rowValues[rkey], value)
+			columnValues[ckey] = append(columnValues[ckey], value)
+			all = append(all, value)
+		}
+	}
+	rows, columns := rowTree.getLeaves(nil), columnTree.getLeaves(nil)
+
+	// create the table with one column for every key of the rows, and one
+	// column for every combination of keys of the columns
+	spec := strings.Repeat("│ l ", nbrows) + "║ r " + strings.Repeat("│ r ", len(columns)-1)
+	if pivot.RowTotals {
+		spec += "║ r "
+	}
+	t, err := NewTable(spec + "│")
+	if err != nil {
+		return &Table{}, err
+	}
+	total := pivot.Total
+	if total == "" {
+		total = "Total"
+	}
+	fill := pivot.Fill
+	if fill == nil {
+		fill = ""
+	}
+
+	// add the headers: one row for every key of the columns with partial
+	// rules between them. The titles of the rows and the label of the totals
+	// are shown in the last one
+	for level := 0; level < nbcolumns; level++ {
+		var cells []any
+		for i := 0; i < nbrows; i++ {
+			var title string
+			if i < len(pivot.Titles) && level == nbcolumns-1 {
+				title = pivot.Titles[i]
+			}
+			cells = append(cells, title)
+		}
+		j := nbrows
+		for _, node := range columnTree.getLevel(level) {
+			m, err := NewMulticolumn(node.getNbLeaves(), t.columns[j].sep+"c", node.key)
+			if err != nil {
+				return &Table{}, err
+			}
+			cells = append(cells, m)
+			j += node.getNbLeaves()
+		}
+		if pivot.RowTotals && level == nbcolumns-1 {
+			cells = append(cells, total)
+		}
+		if err := t.AddRow(cells...); err != nil {
+			return &Table{}, err
+		}
+		if level < nbcolumns-1 {
+			t.AddSingleRule(nbrows, nbrows+len(columns))
+		}
+	}
+	t.AddSingleRule()
+
+	// add the aggregates of all rows
+	var data [][]any
+	for _, row := range rows {
+		record := append([]any(nil), row...)
+		for _, column := range columns {
+			if key := getPivotKey(row, column); found[key] {
+				record = append(record, pivot.reduce(values[key]))
+			} else {
+				record = append(record, fill)
+			}
+		}
+		if pivot.RowTotals {
+			record = append(record, pivot.reduce(rowValues[getPivotKey(row, nil)]))
+		}
+		data = append(data, record)
+	}
+	if nbrows > 1 {
+		levels := make([]Group, nbrows-1)
+		for i := range levels {
+			levels[i] = Group{Column: i}
+		}
+		if err := t.AddGroups(data, levels...); err != nil {
+			return &Table{}, err
+		}
+	} else {
+		for _, record := range data {
+			if err := t.AddRow(record...); err != nil {
+				return &Table{}, err
+			}
+		}
+	}
+
+	// and finally the totals of all columns, if requested
+	if pivot.ColumnTotals {
+		var label any = total
+		if nbrows > 1 {
+			if label, err = NewMulticolumn(nbrows, t.columns[0].sep+"l", total); err != nil {
+				return &Table{}, err
+			}
+		}
+		cells := []any{label}
+		for _, column := range columns {
+			cells = append(cells, pivot.reduce(columnValues[getPivotKey(nil, column)]))
+		}
+		if pivot.RowTotals {
+			cells = append(cells, pivot.reduce(all))
+		}
+		t.AddSingleRule()
+		if err := t.AddRow(cells...); err != nil {
+			return &Table{}, err
+		}
+		t.rows[len(t.rows)-1].footer = true
+	}
+	return t, nil
+}
+
+// Local Variables:
+// mode:go
+// fill-column:80
+// End:
@@ -0,0 +1,106 @@
+// -*- coding: utf-8 -*-
+// pivot_test.go
+// -----------------------------------------------------------------------------
+//
+// Started on <sáb 17-10-2026 02:01:59 (1792202519)>
+//
+
+package table
+
+import (
+	"testing"
+)
+
+func TestNewPivotTable(t *testing.T) {
+
+	treatments := []PivotRecord{
+		{Rows: []any{"Placebo"}, Columns: []any{"Females", "Mortality"}, Value: 0.25},
+		{Rows: []any{"Placebo"}, Columns: []any{"Females", "Pressure"}, Value: 163},
+		{Rows: []any{"Placebo"}, Columns: []any{"Males", "Mortality"}, Value: 0.5},
+		{Rows: []any{"Placebo"}, Columns: []any{"Males", "Pressure"}, Value: 164},
+		{Rows: []any{"ACE"}, Columns: []any{"Females", "Mortality"}, Value: 0.25},
+		{Rows: []any{"ACE"}, Columns: []any{"Males", "Pressure"}, Value: Cell{Value: 144}},
+	}
+	sales := []PivotRecord{
+		{Rows: []any{"North", "A"}, Columns: []any{2023}, Value: 1},
+		{Rows: []any{"North", "A"}, Columns: []any{2023}, Value: 2},
+		{Rows: []any{"North", "B"}, Columns: []any{2024}, Value: 3},
+		{Rows: []any{"South", "A"}, Columns: []any{2024}, Value: 4},
+		{Rows: []any{"South", "A"}, Columns: []any{2023}, Value: "n/a"},
+	}
+
+	tests := []struct {
+		name    string
+		pivot   Pivot
+		records []PivotRecord
+		want    string
+		wantErr bool
+	}{
+		{name: "headers",
+			pivot:   Pivot{Titles: []string{"Treatment"}, Fill: "-", RowTotals: true, ColumnTotals: true},
+			records: treatments,
+			want: `│           ║       Females        │        Males         ║        │
+│           ╟───────────┬──────────┼───────────┬──────────╢        │
+│ Treatment ║ Mortality │ Pressure │ Mortality │ Pressure ║  Total │
+├───────────╫───────────┼──────────┼───────────┼──────────╫────────┤
+│ Placebo   ║      0.25 │      163 │       0.5 │      164 ║ 327.75 │
+│ ACE       ║      0.25 │        - │         - │      144 ║ 144.25 │
+├───────────╫───────────┼──────────┼───────────┼──────────╫────────┤
+│ Total     ║       0.5 │      163 │       0.5 │      308 ║    472 │`},
+		{name: "rows",
+			pivot:   Pivot{Titles: []string{"Region"}, Function: AggregateMean, ColumnTotals: true, Total: "Mean"},
+			records: sales,
+			want: `│ Region │   ║ 2023 │ 2024 │
+├────────┼───╫──────┼──────┤
+│ North  │ A ║  1.5 │      │
+│        │ B ║      │    3 │
+│ South  │ A ║      │    4 │
+├────────┴───╫──────┼──────┤
+│ Mean       ║  1.5 │  3.5 │`},
+		{name: "count",
+			pivot:   Pivot{Function: AggregateCount, Fill: 0, RowTotals: true},
+			records: sales,
+			want: `│       │   ║ 2023 │ 2024 ║ Total │
+├───────┼───╫──────┼──────╫───────┤
+│ North │ A ║    2 │    0 ║     2 │
+│       │ B ║    0 │    1 ║     1 │
+│ South │ A ║    0 │    1 ║     1 │`},
+		{name: "records",
+			wantErr: true},
+		{name: "keys",
+			records: []PivotRecord{{Rows: []any{"a"}, Value: 1}},
+			wantErr: true},
+		{name: "different",
+			records: []PivotRecord{
+				{Rows: []any{"a"}, Columns: []any{"b"}, Value: 1},
+				{Rows: []any{"a", "c"}, Columns: []any{"b"}, Value: 1}},
+			wantErr: true},
+		{name: "titles",
+			pivot:   Pivot{Titles: []string{"a", "b"}},
+			records: treatments,
+			wantErr: true},
+		{name: "function",
+			pivot:   Pivot{Function: Aggregation(7)},
+			records: treatments,
+			wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table, err := NewPivotTable(tt.pivot, tt.records)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewPivotTable() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got := table.String(); got != tt.want {
+				t.Errorf("String() =\n%v\nwant\n%v", got, tt.want)
+			}
+		})
+	}
+}
+
+// Local Variables:
+// mode:go
+// fill-column:80
+// End:
@@ -0,0 +1,158 @@
+// -*- coding: utf-8 -*-
+// query.go
+// -----------------------------------------------------------------------------
+//
+// Started on <sáb 17-10-2026 01:53:20 (1792202000)>
+//
+
+package table
+
+import (
+	"fmt"
+	"unicode/utf8"
+)
+
+// ----------------------------------------------------------------------------
+// Table
+// ----------------------------------------------------------------------------
+
+// Methods
+// ----------------------------------------------------------------------------
+
+// -- Private
+
+// return a copy of this table where the width of all columns and the height of
+// all rows are computed as when drawing it. Hidden columns are removed. It
+// returns an error if the table can not be drawn
+func (t *Table) getLayout() (*Table, error) {
+
+	if err := t.verify(); err != nil {
+		return &Table{}, err
+	}
+	result := t.getVisibleTable()
+	result.layout()
+	return result, nil
+}
+
+// -- Public
+
+// Cell returns the contents of the given location of the table, which can be
+// either ordinary contents, a horizontal rule, the location where a multicell
+// starts or a location taken by a multicell that starts elsewhere. It returns
+// an error if the location does not exist
+func (t *Table) Cell(irow, jcol int) (CellInfo, error) {
+
+	if irow < 0 || irow >= t.GetNbRows() || jcol < 0 || jcol >= t.GetNbColumns() {
+		return CellInfo{}, fmt.Errorf("The location (%v, %v) does not exist", irow, jcol)
+	}
+	info := CellInfo{Row: irow, Column: jcol, Rows: 1, Columns: 1}
+
+	// horizontal rules
+	if t.isRule(irow) {
+		info.Kind = KindRule
+		info.Rule = horizontal_blank
+		if rule, ok := t.cells[irow][jcol].(hrule); ok && rule != "" {
+			info.Rule, _ = utf8.DecodeRuneInString(string(rule))
+		}
+		return info, nil
+	}
+
+	// multicells, either where they start or anywhere else they span
+	if m := t.getMulticell(irow, jcol); m != nil {
+		info.Kind = KindSpanned
+		if m.getRowInit() == irow && m.getColumnInit() == jcol {
+			info.Kind = KindMulticell
+		}
+		info.Text = getCellContents(*m)
+		info.Row, info.Column = m.getRowInit(), m.getColumnInit()
+		info.Rows, info.Columns = m.getNbRows(), m.getNbColumns()
+		table := m.table
+		table.columns = append([]column(nil), table.columns...)
+		table.rows = append([]row(nil), table.rows...)
+		info.Table = &table
+		return info, nil
+	}
+
+	// and ordinary contents
+	info.Kind = KindContent
+	if t.cells[irow][jcol] != nil {
+		info.Text = getCellContents(t.cells[irow][jcol])
+		info.Value = getCellValue(t.cells[irow][jcol])
+	}
+	return info, nil
+}
+
+// Row returns the contents of all columns of the given row, as returned by
+// Cell. It returns an error if the row does not exist
+func (t *Table) Row(irow int) ([]CellInfo, error) {
+
+	var result []CellInfo
+	for j := 0; j < t.GetNbColumns(); j++ {
+		info, err := t.Cell(irow, j)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, info)
+	}
+	return result, nil
+}
+
+// DataRows returns the indices of all rows of data, i.e., all rows but
+// horizontal rules, in ascending order, so that they can be iterated:
+//
+//	for _, i := range t.DataRows() {
+//		cells, _ := t.Row(i)
+//		...
+//	}
+func (t *Table) DataRows() (result []int) {
+
+	for i := 0; i < t.GetNbRows(); i++ {
+		if !t.isRule(i) {
+			result = append(result, i)
+		}
+	}
+	return
+}
+
+// ColumnWidths returns the width (in physical columns, i.e., cells of the
+// terminal) of the contents of all columns, exactly as they are used when
+// drawing the table. The width of separators is not included, and hidden
+// columns have no width. The table is not modified. It returns an error if the
+// table can not be drawn, e.g., because a multirow spans more rows than those
+// added to the table
+func (t *Table) ColumnWidths() ([]int, error) {
+
+	layout, err := t.getLayout()
+	if err != nil {
+		return nil, err
+	}
+	result := make([]int, t.GetNbColumns())
+	for j, k := 0, 0; j < len(result); j++ {
+		if !t.columns[j].hidden {
+			result[j] = layout.columns[k].width
+			k++
+		}
+	}
+	return result, nil
+}
+
+// RowHeights returns the height (in physical lines) of all rows, including
+// horizontal rules, exactly as they are used when drawing the table. The table
+// is not modified. It returns an error if the table can not be drawn
+func (t *Table) RowHeights() ([]int, error) {
+
+	layout, err := t.getLayout()
+	if err != nil {
+		return nil, err
+	}
+	result := make([]int, len(layout.rows))
+	for i, row := range layout.rows {
+		result[i] = row.height
+	}
+	return result, nil
+}
+
+// Local Variables:
+// mode:go
+// fill-column:80
+// End:
@@ -0,0 +1,131 @@
+// -*- coding: utf-8 -*-
+// query_test.go
+// -----------------------------------------------------------------------------
+//
+// Started on <sáb 17-10-2026 01:53:20 (1792202000)>
+//
+
+package table
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTable_Cell(t *testing.T) {
+
+	table, _ := NewTable("| l | r | c |")
+	table.AddRow("Name", "Value", "Unit")
+	table.AddSingleRule(0, 2)
+	table.AddRow("a", 1, Cell{Value: "km", HAlign: 'l'})
+	table.AddRow(Multicolumn(2, "| c", "b", "c"), Multirow(2, "c", "m"))
+	table.AddRow("d", 2)
+
+	tests := []struct {
+		name       string
+		irow, jcol int
+		want       CellInfo
+	}{
+		{name: "content",
+			irow: 2, jcol: 1,
+			want: CellInfo{Kind: KindContent, Text: "1", Value: 1, Row: 2, Column: 1, Rows: 1, Columns: 1}},
+		{name: "cell",
+			irow: 2, jcol: 2,
+			want: CellInfo{Kind: KindContent, Text: "km", Value: "km", Row: 2, Column: 2, Rows: 1, Columns: 1}},
+		{name: "rule",
+			irow: 1, jcol: 0,
+			want: CellInfo{Kind: KindRule, Rule: horizontal_single, Row: 1, Column: 0, Rows: 1, Columns: 1}},
+		{name: "blank rule",
+			irow: 1, jcol: 2,
+			want: CellInfo{Kind: KindRule, Rule: horizontal_blank, Row: 1, Column: 2, Rows: 1, Columns: 1}},
+		{name: "multicolumn",
+			irow: 3, jcol: 0,
+			want: CellInfo{Kind: KindMulticell, Text: "b c", Row: 3, Column: 0, Rows: 1, Columns: 2}},
+		{name: "spanned by a multicolumn",
+			irow: 3, jcol: 1,
+			want: CellInfo{Kind: KindSpanned, Text: "b c", Row: 3, Column: 0, Rows: 1, Columns: 2}},
+		{name: "spanned by a multirow",
+			irow: 4, jcol: 2,
+			want: CellInfo{Kind: KindSpanned, Text: "m", Row: 3, Column: 2, Rows: 2, Columns: 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := table.Cell(tt.irow, tt.jcol)
+			if err != nil {
+				t.Fatalf("Cell() unexpected error: %v", err)
+			}
+			if (got.Table != nil) != (tt.want.Kind == KindMulticell || tt.want.Kind == KindSpanned) {
+				t.Fatalf("Cell() returned an unexpected table: %v", got.Table)
+			}
+			got.Table = nil
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("Cell() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+
+	// nested tables can be inspected as well
+	info, _ := table.Cell(3, 0)
+	if nested, _ := info.Table.Cell(1, 0); nested.Text != "c" {
+		t.Fatalf("Cell() of the nested table = %+v, want c", nested)
+	}
+
+	// and locations out of bounds return an error
+	if _, err := table.Cell(5, 0); err == nil {
+		t.Fatalf("Cell() expected an error")
+	}
+}
+
+func TestTable_DataRows(t *testing.T) {
+
+	table, _ := NewTable("| l | r |")
+	table.AddThickRule()
+	table.AddRow("Name", "Value")
+	table.AddSingleRule()
+	table.AddRow("a", 1)
+	table.AddRow("b", 2)
+	table.AddThickRule()
+	if got, want := table.DataRows(), []int{1, 3, 4}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("DataRows() = %v, want %v", got, want)
+	}
+	row, err := table.Row(4)
+	if err != nil || len(row) != 2 || row[0].Text != "b" || row[1].Text != "2" {
+		t.Fatalf("Row() = %+v (%v)", row, err)
+	}
+}
+
+func TestTable_Layout_Sizes(t *testing.T) {
+
+	table, _ := NewTable("| l | r | c |")
+	table.AddRow("Name", "Value", "Unit")
+	table.AddRow("a", "1\n2", "km")
+	table.AddRow(Multicolumn(2, "| c", "a very long multicolumn"), "m")
+
+	// the multicolumn is wider than both columns so that they are enlarged
+	if got, err := table.ColumnWidths(); err != nil || !reflect.DeepEqual(got, []int{10, 10, 4}) {
+		t.Fatalf("ColumnWidths() = %v, %v, want %v", got, err, []int{10, 10, 4})
+	}
+	if got, err := table.RowHeights(); err != nil || !reflect.DeepEqual(got, []int{1, 2, 1}) {
+		t.Fatalf("RowHeights() = %v, %v, want %v", got, err, []int{1, 2, 1})
+	}
+
+	// hidden columns have no width
+	table.HideColumn(2)
+	if got, err := table.ColumnWidths(); err != nil || !reflect.DeepEqual(got, []int{10, 10, 0}) {
+		t.Fatalf("ColumnWidths() = %v, %v, want %v", got, err, []int{10, 10, 0})
+	}
+
+	// tables which can not be drawn have no layout
+	table.AddRow(Multirow(3, "c", "x"))
+	if _, err := table.ColumnWidths(); err == nil {
+		t.Errorf("ColumnWidths() did not return an error")
+	}
+	if _, err := table.RowHeights(); err == nil {
+		t.Errorf("RowHeights() did not return an error")
+	}
+}
+
+// Local Variables:
+// mode:go
+// fill-column:80
+// End:
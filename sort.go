@@ -0,0 +1,292 @@
+// -*- coding: utf-8 -*-
+// sort.go
+// -----------------------------------------------------------------------------
+//
+// Started on <sáb 17-10-2026 01:46:48 (1792201608)>
+//
+
+package table
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// ----------------------------------------------------------------------------
+// Sorting
+// ----------------------------------------------------------------------------
+
+// Functions
+// ----------------------------------------------------------------------------
+
+// -- Private
+
+// return the number represented by the given string and true, or false if it
+// does not represent a number. Numbers can use commas as thousands separators
+func parseNumber(s string) (float64, bool) {
+
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, false
+	}
+	value, err := strconv.ParseFloat(strings.ReplaceAll(s, ",", ""), 64)
+	return value, err == nil
+}
+
+// return -1, 0 or +1 depending on whether the first integer is less, equal or
+// greater than the second one
+func compareInts(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return +1
+	}
+	return 0
+}
+
+// compare both strings numerically: numbers are sorted before any other string,
+// and strings which are not numbers are compared lexicographically
+func compareNumeric(a, b string) int {
+
+	x, okx := parseNumber(a)
+	y, oky := parseNumber(b)
+	switch {
+	case okx && oky:
+		if x < y {
+			return -1
+		} else if x > y {
+			return +1
+		}
+		return 0
+	case okx:
+		return -1
+	case oky:
+		return +1
+	}
+	return strings.Compare(a, b)
+}
+
+// compare both strings in natural order, i.e., runs of digits are compared
+// numerically so that "item2" is sorted before "item10"
+func compareNatural(a, b string) int {
+
+	isDigit := func(c byte) bool { return '0' <= c && c <= '9' }
+	for a != "" && b != "" {
+
+		// runs of digits are compared by their value, i.e., first by their
+		// length (once leading zeros are removed) and then lexicographically
+		if isDigit(a[0]) && isDigit(b[0]) {
+			na, nb := 1, 1
+			for na < len(a) && isDigit(a[na]) {
+				na++
+			}
+			for nb < len(b) && isDigit(b[nb]) {
+				nb++
+			}
+			da, db := strings.TrimLeft(a[:na], "0"), strings.TrimLeft(b[:nb], "0")
+			if result := compareInts(len(da), len(db)); result != 0 {
+				return result
+			}
+			if result := strings.Compare(da, db); result != 0 {
+				return result
+			}
+			a, b = a[na:], b[nb:]
+			continue
+		}
+
+		// other runes are compared one by one
+		ra, sa := utf8.DecodeRuneInString(a)
+		rb, sb := utf8.DecodeRuneInString(b)
+		if result := compareInts(int(ra), int(rb)); result != 0 {
+			return result
+		}
+		a, b = a[sa:], b[sb:]
+	}
+	return compareInts(len(a), len(b))
+}
+
+// Methods
+// ----------------------------------------------------------------------------
+
+// -- Private
+
+// compare both strings with this key, and return a negative number if the first
+// one has to be sorted before the second one, a positive number if it has to be
+// sorted after, and zero otherwise
+func (k SortKey) compare(a, b string) (result int) {
+
+	switch {
+	case k.Compare != nil:
+		result = k.Compare(a, b)
+	case k.Comparison == CompareString:
+		result = strings.Compare(a, b)
+	case k.Comparison == CompareNumeric:
+		result = compareNumeric(a, b)
+	case k.Comparison == CompareNatural:
+		result = compareNatural(a, b)
+	default:
+		_, okx := parseNumber(a)
+		_, oky := parseNumber(b)
+		if okx && oky {
+			result = compareNumeric(a, b)
+		} else {
+			result = compareNatural(a, b)
+		}
+	}
+	if k.Descending {
+		return -result
+	}
+	return result
+}
+
+// compare the given rows with this key, and return a negative number if the
+// first one has to be sorted before the second one, a positive number if it has
+// to be sorted after, and zero otherwise. Unless strings have to be compared,
+// cells whose values are numbers of any numerical type are compared by their
+// value, and any other cells are compared with the text shown in them
+func (t *Table) compareRows(key SortKey, i, j int) int {
+
+	if key.Compare == nil && (key.Comparison == CompareAuto || key.Comparison == CompareNumeric) {
+		x, okx := toNumber(getCellValue(t.cells[i][key.Column]))
+		y, oky := toNumber(getCellValue(t.cells[j][key.Column]))
+		if okx && oky {
+			result := 0
+			if x < y {
+				result = -1
+			} else if x > y {
+				result = +1
+			}
+			if key.Descending {
+				return -result
+			}
+			return result
+		}
+	}
+	return key.compare(t.getSortText(i, key.Column), t.getSortText(j, key.Column))
+}
+
+// return the text shown in the given location used to sort rows. Locations
+// taken by a multicolumn return the text of the multicolumn
+func (t *Table) getSortText(irow, jcol int) string {
+
+	if m := t.getMulticell(irow, jcol); m != nil {
+		return getCellText(*m)
+	}
+	return getCellText(t.cells[irow][jcol])
+}
+
+// return an error if any multicell spanning several rows reaches any row in the
+// interval [init, end)
+func (t *Table) verifySortBlock(init, end int) error {
+
+	for i := 0; i < end; i++ {
+		for j := range t.cells[i] {
+			if m, ok := t.cells[i][j].(multicell); ok && m.getNbRows() > 1 && m.getRowInit()+m.getNbRows() > init {
+				return fmt.Errorf("The rows from %v to %v can not be sorted because the multicell in location (%v, %v) spans several rows",
+					init, end-1, i, j)
+			}
+		}
+	}
+	return nil
+}
+
+// sort the rows in the interval [init, end) with the given keys. Multicells in
+// these rows are updated with their new location
+func (t *Table) sortRows(init, end int, keys []SortKey) {
+
+	// sort the indices of all rows in this block
+	perm := make([]int, end-init)
+	for i := range perm {
+		perm[i] = init + i
+	}
+	sort.SliceStable(perm, func(a, b int) bool {
+		for _, key := range keys {
+			if result := t.compareRows(key, perm[a], perm[b]); result != 0 {
+				return result < 0
+			}
+		}
+		return false
+	})
+
+	// and move the cells and rows accordingly
+	cells, rows := make([][]formatter, len(perm)), make([]row, len(perm))
+	for i, p := range perm {
+		cells[i], rows[i] = t.cells[p], t.rows[p]
+	}
+	copy(t.cells[init:end], cells)
+	copy(t.rows[init:end], rows)
+
+	// multicells store the row where they start, which has to be updated
+	for i := init; i < end; i++ {
+		for j := range t.cells[i] {
+			if m, ok := t.cells[i][j].(multicell); ok {
+				m.iinit = i
+				t.cells[i][j] = m
+			}
+		}
+	}
+}
+
+// -- Public
+
+// SortBy sorts the data rows of the table with the given keys: rows are
+// compared with the first key and, in case of a tie, with the next one, and so
+// on. Rows which are equal with all keys preserve their relative order.
+//
+// Horizontal rules are never moved, and they divide the table in blocks of data
+// rows (e.g., the header, body and footer of the table) which are sorted
+// separately. Rows taken by multicolumns can be sorted and the text of the
+// multicolumn is used in all the columns it spans, but blocks with multicells
+// spanning several rows can not be sorted.
+//
+// It returns an error if no key is given, any key refers to a column which does
+// not exist, or a block that has to be sorted contains multicells spanning
+// several rows. In case of error, the table is not modified
+func (t *Table) SortBy(keys ...SortKey) error {
+
+	if len(keys) == 0 {
+		return errors.New("At least one key must be given to sort the rows of a table")
+	}
+	for _, key := range keys {
+		if key.Column < 0 || key.Column >= t.GetNbColumns() {
+			return fmt.Errorf("The column %v used to sort the rows does not exist", key.Column)
+		}
+	}
+
+	// compute all blocks of data rows with more than one row, and verify them
+	// all before sorting any
+	var blocks [][2]int
+	for i := 0; i < len(t.rows); {
+		if t.isRule(i) {
+			i++
+			continue
+		}
+		end := i + 1
+		for end < len(t.rows) && !t.isRule(end) {
+			end++
+		}
+		if end-i > 1 {
+			if err := t.verifySortBlock(i, end); err != nil {
+				return err
+			}
+			blocks = append(blocks, [2]int{i, end})
+		}
+		i = end
+	}
+
+	// and now sort all blocks
+	for _, block := range blocks {
+		t.sortRows(block[0], block[1], keys)
+	}
+	return nil
+}
+
+// Local Variables:
+// mode:go
+// fill-column:80
+// End:
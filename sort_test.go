@@ -0,0 +1,149 @@
+// -*- coding: utf-8 -*-
+// sort_test.go
+// -----------------------------------------------------------------------------
+//
+// Started on <sáb 17-10-2026 01:46:48 (1792201608)>
+//
+
+package table
+
+import (
+	"strings"
+	"testing"
+)
+
+func Test_compareNatural(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{a: "item2", b: "item10", want: -1},
+		{a: "item10", b: "item2", want: +1},
+		{a: "item02", b: "item2", want: 0},
+		{a: "a", b: "b", want: -1},
+		{a: "file1.txt", b: "file1", want: +1},
+		{a: "", b: "", want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.a+"/"+tt.b, func(t *testing.T) {
+			if got := compareNatural(tt.a, tt.b); got != tt.want {
+				t.Errorf("compareNatural() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTable_SortBy(t *testing.T) {
+
+	// return the text of the first column of all rows, where rules are shown
+	// as dashes
+	column := func(t *Table) string {
+		var result []string
+		for i := range t.cells {
+			if t.isRule(i) {
+				result = append(result, "-")
+			} else {
+				result = append(result, t.getSortText(i, 0))
+			}
+		}
+		return strings.Join(result, " ")
+	}
+
+	tests := []struct {
+		name string
+		keys []SortKey
+		want string
+	}{
+		{name: "auto",
+			keys: []SortKey{{Column: 1}},
+			want: "Name - bob dan amy carl eve - Total"},
+		{name: "descending",
+			keys: []SortKey{{Column: 1, Descending: true}},
+			want: "Name - eve carl amy dan bob - Total"},
+		{name: "string",
+			keys: []SortKey{{Column: 1, Comparison: CompareString}},
+			want: "Name - carl dan amy bob eve - Total"},
+		{name: "numeric",
+			keys: []SortKey{{Column: 1, Comparison: CompareNumeric}},
+			want: "Name - bob dan amy carl eve - Total"},
+		{name: "natural",
+			keys: []SortKey{{Column: 2, Comparison: CompareNatural}},
+			want: "Name - bob dan eve amy carl - Total"},
+		{name: "several keys",
+			keys: []SortKey{{Column: 3}, {Column: 0, Descending: true}},
+			want: "Name - eve dan bob carl amy - Total"},
+		{name: "custom",
+			keys: []SortKey{{Column: 0, Compare: func(a, b string) int {
+				return len(a) - len(b)
+			}}},
+			want: "Name - amy bob dan eve carl - Total"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table, _ := NewTable("l r l l")
+			table.AddRow("Name", "Score", "File", "Group")
+			table.AddSingleRule()
+			table.AddRow("amy", "12", "file10", "b")
+			table.AddRow("bob", "9.5", "file1", "a")
+			table.AddRow("carl", "1,200", "file20", "b")
+			table.AddRow("dan", "10", "file2", "a")
+			table.AddRow("eve", "n/a", "file3", "a")
+			table.AddSingleRule()
+			table.AddRow("Total", "1,231.5")
+			if err := table.SortBy(tt.keys...); err != nil {
+				t.Fatalf("SortBy() unexpected error: %v", err)
+			}
+			if got := column(table); got != tt.want {
+				t.Fatalf("SortBy() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTable_SortBy_Multicells(t *testing.T) {
+
+	// multicolumns are moved along with their rows
+	table, _ := NewTable("| c | c |")
+	table.AddRow("b", "2")
+	table.AddRow(Multicolumn(2, "| c |", "a"))
+	table.AddRow("c", "3")
+	if err := table.SortBy(SortKey{Column: 1}); err != nil {
+		t.Fatalf("SortBy() unexpected error: %v", err)
+	}
+	want := `│ b │ 2 │
+│ c │ 3 │
+│   a   │`
+	if got, err := table.Render(); err != nil || got != want {
+		t.Fatalf("SortBy() = %q (%v), want %q", got, err, want)
+	}
+
+	// but multirows can not be sorted
+	table, _ = NewTable("| c | c |")
+	table.AddRow(Multirow(2, "c", "a"), "2")
+	table.AddRow("1")
+	table.AddSingleRule()
+	table.AddRow("b", "3")
+	want = table.String()
+	if err := table.SortBy(SortKey{Column: 1}); err == nil {
+		t.Fatalf("SortBy() expected an error")
+	}
+	if got := table.String(); got != want {
+		t.Fatalf("SortBy() modified the table: %q, want %q", got, want)
+	}
+}
+
+func TestTable_SortBy_Errors(t *testing.T) {
+	table, _ := NewTable("l l")
+	table.AddRow("a", "b")
+	if err := table.SortBy(); err == nil {
+		t.Fatalf("SortBy() expected an error with no keys")
+	}
+	if err := table.SortBy(SortKey{Column: 2}); err == nil {
+		t.Fatalf("SortBy() expected an error with an incorrect column")
+	}
+}
+
+// Local Variables:
+// mode:go
+// fill-column:80
+// End:
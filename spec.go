@@ -0,0 +1,290 @@
+// -*- coding: utf-8 -*-
+// spec.go
+// -----------------------------------------------------------------------------
+//
+// Started on <sáb 17-10-2026 01:41:41 (1792201301)>
+//
+
+package table
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// ----------------------------------------------------------------------------
+// SpecError
+// ----------------------------------------------------------------------------
+
+// Methods
+// ----------------------------------------------------------------------------
+
+// -- Public
+
+// Error returns a description of the error with its location in the
+// specification, the offending token and the alternatives that were expected
+func (e *SpecError) Error() string {
+
+	found := "end of specification"
+	if e.Token != "" {
+		found = fmt.Sprintf("'%v'", e.Token)
+	}
+	var expected string
+	switch len(e.Expected) {
+	case 0:
+		expected = "the end of the specification"
+	case 1:
+		expected = e.Expected[0]
+	default:
+		expected = "one of " + strings.Join(e.Expected, ", ")
+	}
+	return fmt.Sprintf("invalid %v specification: unexpected %v at offset %v, expected %v",
+		e.Kind, found, e.Offset, expected)
+}
+
+// Caret returns the specification (with no ANSI color escape sequences) in a
+// first line, and a caret pointing to the offending token in a second line,
+// e.g.:
+//
+//	| c | p |
+//	      ^
+func (e *SpecError) Caret() string {
+
+	width := countPrintableRuneInString(e.Spec[:min(e.Offset, len(e.Spec))])
+	return stripANSIColors(e.Spec) + "\n" + strings.Repeat(" ", width) + "^"
+}
+
+// ----------------------------------------------------------------------------
+// ColumnSpec
+// ----------------------------------------------------------------------------
+
+// Functions
+// ----------------------------------------------------------------------------
+
+// Column returns the specification of a column with no separator whose
+// contents are ragged right and aligned to the top, i.e., "l". It can be
+// modified with the methods of ColumnSpec, e.g.:
+//
+//	Column().SepBefore(Double).Text(" ").Left().Width(25)
+//
+// is equivalent to the specification "|| L{25}"
+func Column() ColumnSpec {
+	return ColumnSpec{alignment: 'l', valign: 't'}
+}
+
+// Last returns the specification of the last separator of a table, i.e., a
+// column with no contents which only consists of a separator. It can be given
+// only as the last column of a table
+func Last() ColumnSpec {
+	return ColumnSpec{}
+}
+
+// Spec returns the column and row specifications (as accepted by NewTable) of
+// the given columns. It returns an error if any column is incorrect or the
+// last separator is not given last
+func Spec(columns ...ColumnSpec) (colspec, rowspec string, err error) {
+
+	if len(columns) == 0 || columns[0].alignment == 0 {
+		return "", "", errors.New("at least one column with contents has to be given")
+	}
+	for j, col := range columns {
+		if col.err != nil {
+			return "", "", fmt.Errorf("column %v: %v", j, col.err)
+		}
+		if col.alignment == 0 && j < len(columns)-1 {
+			return "", "", errors.New("the last separator can be given only after all columns")
+		}
+		colspec += col.String()
+		if col.alignment != 0 {
+			rowspec += string(col.valign)
+		}
+	}
+	return
+}
+
+// NewTableFromColumns creates a new table with the given columns, which is
+// exactly the same table created by NewTable with the column and row
+// specifications computed by Spec. It returns an error if any column is
+// incorrect or the last separator is not given last
+func NewTableFromColumns(columns ...ColumnSpec) (*Table, error) {
+
+	colspec, rowspec, err := Spec(columns...)
+	if err != nil {
+		return &Table{}, err
+	}
+	return NewTable(colspec, rowspec)
+}
+
+// Methods
+// ----------------------------------------------------------------------------
+
+// -- Private
+
+// return a copy of this specification with the given error unless it already
+// had one
+func (c ColumnSpec) fail(err error) ColumnSpec {
+	if c.err == nil {
+		c.err = err
+	}
+	return c
+}
+
+// return a copy of this specification with the given horizontal alignment.
+// Last separators can not be given any alignment
+func (c ColumnSpec) align(alignment byte) ColumnSpec {
+	if c.alignment == 0 {
+		return c.fail(errors.New("the last separator can not be given any alignment"))
+	}
+	c.alignment = alignment
+	return c
+}
+
+// -- Public
+
+// SepBefore adds the given vertical separator to the text shown before the
+// column
+func (c ColumnSpec) SepBefore(sep Separator) ColumnSpec {
+
+	switch sep {
+	case Single:
+		c.sep += string(vertical_single)
+	case Double:
+		c.sep += string(vertical_double)
+	case Thick:
+		c.sep += string(vertical_thick)
+	default:
+		return c.fail(fmt.Errorf("unknown separator %v", sep))
+	}
+	return c
+}
+
+// Text adds the given text to the text shown before the column. It can not
+// contain any of the characters used to specify the alignment of columns
+func (c ColumnSpec) Text(text string) ColumnSpec {
+
+	if strings.ContainsAny(text, "clrdCLRp") {
+		return c.fail(fmt.Errorf("the text '%v' contains characters used to specify columns", text))
+	}
+	c.sep += text
+	return c
+}
+
+// Color adds an ANSI color escape sequence to the text shown before the column
+// so that the text added afterwards (and also the following columns) is shown
+// with the given RGB color
+func (c ColumnSpec) Color(r, g, b uint8) ColumnSpec {
+	c.sep += fmt.Sprintf("\033[38;2;%v;%v;%vm", r, g, b)
+	return c
+}
+
+// Reset adds an ANSI escape sequence to the text shown before the column which
+// resets all colors
+func (c ColumnSpec) Reset() ColumnSpec {
+	c.sep += ansiReset
+	return c
+}
+
+// Left makes the contents of the column ragged right ('l')
+func (c ColumnSpec) Left() ColumnSpec {
+	return c.align('l')
+}
+
+// Center makes the contents of the column horizontally centered ('c')
+func (c ColumnSpec) Center() ColumnSpec {
+	return c.align('c')
+}
+
+// Right makes the contents of the column ragged left ('r')
+func (c ColumnSpec) Right() ColumnSpec {
+	return c.align('r')
+}
+
+// Decimal aligns numbers on the decimal point ('d'). Columns with a width can
+// not be aligned on the decimal point
+func (c ColumnSpec) Decimal() ColumnSpec {
+
+	if c.width != 0 {
+		return c.fail(errors.New("decimal columns can not be given a width"))
+	}
+	return c.align('d')
+}
+
+// Paragraph fixes the width of the column and its contents are split across
+// various lines if needed ('p')
+func (c ColumnSpec) Paragraph(width int) ColumnSpec {
+	c = c.align('p').Width(width)
+	return c
+}
+
+// Width sets the maximum width of the column, and its contents are split across
+// various lines if needed with the same alignment ('L', 'C' or 'R')
+func (c ColumnSpec) Width(width int) ColumnSpec {
+
+	if width <= 0 {
+		return c.fail(fmt.Errorf("invalid width %v", width))
+	}
+	if c.alignment == 'd' {
+		return c.fail(errors.New("decimal columns can not be given a width"))
+	}
+	c.width, c.truncate = width, false
+	return c
+}
+
+// Truncate fixes the width of the column, and its contents are shown in a
+// single line which is truncated if needed with the same alignment ('l', 'c'
+// or 'r'). Neither paragraphs nor decimal columns can be truncated
+func (c ColumnSpec) Truncate(width int) ColumnSpec {
+
+	if width <= 0 {
+		return c.fail(fmt.Errorf("invalid width %v", width))
+	}
+	if c.alignment == 'd' {
+		return c.fail(errors.New("decimal columns can not be given a width"))
+	}
+	if c.alignment == 'p' {
+		return c.fail(errors.New("paragraphs can not be truncated"))
+	}
+	c.width, c.truncate = width, true
+	return c
+}
+
+// Top aligns the contents of the column to the top ('t')
+func (c ColumnSpec) Top() ColumnSpec {
+	c.valign = 't'
+	return c
+}
+
+// Middle vertically centers the contents of the column ('c')
+func (c ColumnSpec) Middle() ColumnSpec {
+	c.valign = 'c'
+	return c
+}
+
+// Bottom aligns the contents of the column to the bottom ('b')
+func (c ColumnSpec) Bottom() ColumnSpec {
+	c.valign = 'b'
+	return c
+}
+
+// String returns this column as it is given in a column specification, i.e.,
+// the text shown before the column followed by its alignment. Note that
+// columns which are not correct might return an incorrect specification
+func (c ColumnSpec) String() string {
+
+	switch {
+	case c.alignment == 0:
+		return c.sep
+	case c.width == 0:
+		return c.sep + string(c.alignment)
+	case c.truncate || c.alignment == 'p':
+		return fmt.Sprintf("%v%c{%v}", c.sep, c.alignment, c.width)
+	}
+	return fmt.Sprintf("%v%c{%v}", c.sep, unicode.ToUpper(rune(c.alignment)), c.width)
+}
+
+// Local Variables:
+// mode:go
+// fill-column:80
+// End:
@@ -0,0 +1,202 @@
+// -*- coding: utf-8 -*-
+// spec_test.go
+// -----------------------------------------------------------------------------
+//
+// Started on <sáb 17-10-2026 01:41:41 (1792201301)>
+//
+
+package table
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestSpecError(t *testing.T) {
+	tests := []struct {
+		name    string
+		colspec string
+		rowspec string
+		kind    string
+		offset  int
+		token   string
+		caret   string
+	}{
+		{name: "no columns",
+			colspec: "| |",
+			kind:    "column",
+			offset:  3,
+			token:   "",
+			caret:   "| |\n   ^"},
+		{name: "paragraph with no argument",
+			colspec: "| c | p |",
+			kind:    "column",
+			offset:  6,
+			token:   "p",
+			caret:   "| c | p |\n      ^"},
+		{name: "wrapped column with no argument",
+			colspec: "| c | L{} |",
+			kind:    "column",
+			offset:  6,
+			token:   "L{}",
+			caret:   "| c | L{} |\n      ^"},
+		{name: "null argument",
+			colspec: "\033[38;2;160;10;10m║ c │ p{0} │",
+			kind:    "column",
+			offset:  27,
+			token:   "p{0}",
+			caret:   "║ c │ p{0} │\n      ^"},
+		{name: "decimal column in a word",
+			colspec: "| l | id: r |",
+			kind:    "column",
+			offset:  7,
+			token:   "d",
+			caret:   "| l | id: r |\n       ^"},
+		{name: "decimal column before a word",
+			colspec: "| c | dx |",
+			kind:    "column",
+			offset:  6,
+			token:   "d",
+			caret:   "| c | dx |\n      ^"},
+		{name: "incorrect vertical format",
+			colspec: "c c c",
+			rowspec: "tx",
+			kind:    "row",
+			offset:  1,
+			token:   "x",
+			caret:   "tx\n ^"},
+		{name: "too many vertical formats",
+			colspec: "c c",
+			rowspec: "tbc",
+			kind:    "row",
+			offset:  2,
+			token:   "c",
+			caret:   "tbc\n  ^"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewTable(tt.colspec, tt.rowspec)
+			var serr *SpecError
+			if !errors.As(err, &serr) {
+				t.Fatalf("NewTable() error = %v, want a *SpecError", err)
+			}
+			if serr.Kind != tt.kind || serr.Offset != tt.offset || serr.Token != tt.token {
+				t.Fatalf("NewTable() error = (%v, %v, %q), want (%v, %v, %q)",
+					serr.Kind, serr.Offset, serr.Token, tt.kind, tt.offset, tt.token)
+			}
+			if got := serr.Caret(); got != tt.caret {
+				t.Fatalf("Caret() = %q, want %q", got, tt.caret)
+			}
+		})
+	}
+}
+
+func TestNewMulticell_SpecError(t *testing.T) {
+	_, err := NewMulticell(2, 1, "c | R |", "t")
+	var serr *SpecError
+	if !errors.As(err, &serr) {
+		t.Fatalf("NewMulticell() error = %v, want a *SpecError", err)
+	}
+	if serr.Offset != 4 || serr.Token != "R" {
+		t.Fatalf("NewMulticell() error = (%v, %q), want (4, \"R\")", serr.Offset, serr.Token)
+	}
+}
+
+func TestNewTableFromColumns(t *testing.T) {
+	tests := []struct {
+		name    string
+		columns []ColumnSpec
+		colspec string
+		rowspec string
+	}{
+		{name: "single column",
+			columns: []ColumnSpec{Column()},
+			colspec: "l",
+			rowspec: "t"},
+		{name: "separators",
+			columns: []ColumnSpec{Column().SepBefore(Single).Text(" ").Center(),
+				Column().Text(" ").SepBefore(Double).Text(" ").Right(),
+				Last().Text(" ").SepBefore(Thick)},
+			colspec: "│ c ║ r ┃",
+			rowspec: "tt"},
+		{name: "widths",
+			columns: []ColumnSpec{Column().SepBefore(Single).Left().Width(25),
+				Column().SepBefore(Single).Paragraph(10).Bottom(),
+				Column().SepBefore(Single).Center().Truncate(8).Middle(),
+				Column().SepBefore(Single).Decimal(),
+				Last().SepBefore(Single)},
+			colspec: "│L{25}│p{10}│c{8}│d│",
+			rowspec: "tbct"},
+		{name: "colors",
+			columns: []ColumnSpec{Column().Color(160, 10, 10).SepBefore(Single).Text(" ").Center(),
+				Column().Text(" ").Color(10, 160, 10).SepBefore(Single).Text(" ").Center(),
+				Last().Text(" ").SepBefore(Single).Reset()},
+			colspec: "\033[38;2;160;10;10m│ c \033[38;2;10;160;10m│ c │\033[0m",
+			rowspec: "tt"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			colspec, rowspec, err := Spec(tt.columns...)
+			if err != nil {
+				t.Fatalf("Spec() unexpected error: %v", err)
+			}
+			if colspec != tt.colspec || rowspec != tt.rowspec {
+				t.Fatalf("Spec() = (%q, %q), want (%q, %q)", colspec, rowspec, tt.colspec, tt.rowspec)
+			}
+			got, err := NewTableFromColumns(tt.columns...)
+			if err != nil {
+				t.Fatalf("NewTableFromColumns() unexpected error: %v", err)
+			}
+			want, err := NewTable(tt.colspec, tt.rowspec)
+			if err != nil {
+				t.Fatalf("NewTable() unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got.columns, want.columns) {
+				t.Fatalf("NewTableFromColumns() = %v, want %v", got.columns, want.columns)
+			}
+		})
+	}
+}
+
+func TestNewTableFromColumns_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		columns []ColumnSpec
+	}{
+		{name: "no columns",
+			columns: []ColumnSpec{}},
+		{name: "only the last separator",
+			columns: []ColumnSpec{Last().SepBefore(Single)}},
+		{name: "last separator before a column",
+			columns: []ColumnSpec{Column(), Last(), Column()}},
+		{name: "alignment of the last separator",
+			columns: []ColumnSpec{Column(), Last().Center()}},
+		{name: "negative width",
+			columns: []ColumnSpec{Column().Width(-1)}},
+		{name: "null paragraph",
+			columns: []ColumnSpec{Column().Paragraph(0)}},
+		{name: "truncated decimal",
+			columns: []ColumnSpec{Column().Decimal().Truncate(10)}},
+		{name: "truncated paragraph",
+			columns: []ColumnSpec{Column().Paragraph(10).Truncate(5)}},
+		{name: "decimal with a width",
+			columns: []ColumnSpec{Column().Width(10).Decimal()}},
+		{name: "text with alignments",
+			columns: []ColumnSpec{Column().Text(" and ")}},
+		{name: "unknown separator",
+			columns: []ColumnSpec{Column().SepBefore(Separator(7))}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewTableFromColumns(tt.columns...); err == nil {
+				t.Fatalf("NewTableFromColumns() expected an error")
+			}
+		})
+	}
+}
+
+// Local Variables:
+// mode:go
+// fill-column:80
+// End:
@@ -0,0 +1,267 @@
+// -*- coding: utf-8 -*-
+// stream.go
+// -----------------------------------------------------------------------------
+//
+// Started on <sáb 17-10-2026 01:22:58 (1792200178)>
+//
+
+package table
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+)
+
+// ----------------------------------------------------------------------------
+// StreamWriter
+// ----------------------------------------------------------------------------
+
+// Functions
+// ----------------------------------------------------------------------------
+
+// NewStreamWriter creates a new stream writer which writes its rows and rules
+// to the given writer as soon as they are added. The column and row
+// specifications are given as in NewTable.
+//
+// Because rows are written immediately, the width of every column has to be
+// known in advance. Columns with a paragraph alignment (p, C, L, R) or whose
+// contents are truncated (c{N}, l{N}, r{N}) take the width given in their
+// specification. Any other column ('l', 'c' and 'r') must be given an explicit
+// width in the slice of widths, which contains one entry per column (a value
+// equal to zero means that the width is taken from the column specification)
+// and it then behaves as a 'L', 'C' or 'R' column respectively, i.e., contents
+// exceeding its width are split across various lines. An explicit width given
+// to any other column overrides its specification.
+//
+// Columns where numbers are aligned on the decimal point ('d') are not allowed,
+// as the numbers to align are not known in advance.
+//
+// NewStreamWriter returns an error in case either the column or row
+// specification could not be processed, or the width of any column is not
+// known.
+func NewStreamWriter(w io.Writer, widths []int, spec ...string) (*StreamWriter, error) {
+
+	// first things first, create a table with the given specification
+	t, err := NewTable(spec...)
+	if err != nil {
+		return &StreamWriter{}, err
+	}
+
+	// verify that there are not more widths than columns
+	if len(widths) > t.GetNbColumns() {
+		return &StreamWriter{}, fmt.Errorf("The number of widths given (%v) exceeds the number of columns (%v)",
+			len(widths), t.GetNbColumns())
+	}
+
+	// and now fix the width of every column
+	for j := 0; j < t.GetNbColumns(); j++ {
+
+		// aliasing
+		col := &t.columns[j]
+
+		// numbers can not be aligned on the decimal point without knowing
+		// all of them in advance
+		if col.hformat.isDecimal() {
+			return &StreamWriter{}, fmt.Errorf("column %v aligns numbers on the decimal point and can not be written to a stream", j)
+		}
+
+		// in case an explicit width has been given, then use it
+		if j < len(widths) && widths[j] > 0 {
+
+			// columns with no paragraph alignment are transformed into columns
+			// that do not exceed the given width with the same alignment,
+			// unless their contents are truncated
+			if !col.hformat.isParagraph() && !col.hformat.isTruncated() {
+				col.hformat.alignment = byte(unicode.ToUpper(rune(col.hformat.alignment)))
+			}
+			col.hformat.arg = widths[j]
+		} else if j < len(widths) && widths[j] < 0 {
+			return &StreamWriter{}, fmt.Errorf("invalid width (%v) given to column %v", widths[j], j)
+		}
+
+		// at this point, the width of every column must be known
+		if !col.hformat.isParagraph() && !col.hformat.isTruncated() {
+			return &StreamWriter{}, fmt.Errorf("the width of column %v is unknown. Either use a paragraph alignment or give an explicit width", j)
+		}
+		col.width = col.hformat.arg
+	}
+
+	// and return the stream writer
+	return &StreamWriter{writer: w, columns: t.columns, theme: t.theme}, nil
+}
+
+// Methods
+// ----------------------------------------------------------------------------
+
+// -- Private
+
+// return a new table which has the same columns than the receiver with no rows
+// at all. Columns are copied so that the widths of the columns of the receiver
+// are never modified
+func (s *StreamWriter) newTable() *Table {
+
+	columns := make([]column, len(s.columns))
+	copy(columns, s.columns)
+	return &Table{columns: columns, theme: s.theme}
+}
+
+// render the only row of the given table and write all its physical lines
+// after verifying that the width of none of its columns has been modified. If
+// the row is a horizontal rule, its line is kept pending until the next one is
+// known
+func (s *StreamWriter) render(t *Table) error {
+
+	// draw the contents of this table with the UTF-8 box drawing characters.
+	// Note that drawing a table might modify the width of its columns (e.g.,
+	// if a multicolumn requires more space)
+	lines, err := t.render()
+	if err != nil {
+		return err
+	}
+	for j := range s.columns {
+		if t.columns[j].width != s.columns[j].width {
+			return fmt.Errorf("the contents of column %v exceed its width (%v)", j, s.columns[j].width)
+		}
+	}
+
+	// and write these lines
+	return s.write(lines, t.isRule(0))
+}
+
+// write the given physical lines. Splitters are drawn only in horizontal rules
+// and they depend on both the lines above and below. Hence, lines of data are
+// written immediately, whereas the line of a horizontal rule is kept pending
+// until either more lines are given or the stream writer is flushed. The
+// splitters of the new lines are then computed along with the last line
+// written and the pending one, and lines are written with the runes of the
+// theme of the stream writer
+func (s *StreamWriter) write(lines []string, rule bool) error {
+
+	// create a window with the last line written, if any, the pending line and
+	// all the new ones
+	var window []string
+	if s.started {
+		window = append(window, s.last)
+	}
+	if s.pending {
+		window = append(window, s.next)
+	}
+	window = append(window, s.theme.normalizeLines(lines)...)
+
+	// insert all splitters. The number of lines in the window that have been
+	// written already is equal to 1 if the stream was started and 0 otherwise
+	addSplitters(window)
+	written := 0
+	if s.started {
+		written = 1
+	}
+
+	// and now write all lines but the last one if it is a horizontal rule,
+	// which is kept pending
+	end := len(window)
+	if rule {
+		end--
+	}
+	s.pending = false
+	for _, line := range window[written:end] {
+		if _, err := fmt.Fprintln(s.writer, strings.Map(s.theme.translate, line)); err != nil {
+			return err
+		}
+		s.last, s.started = line, true
+	}
+	if rule {
+		s.next, s.pending = window[end], true
+	}
+	return nil
+}
+
+// -- Public
+
+// Add a new line of data to the stream writer and write it immediately. It
+// accepts the same arguments than Table.AddRow with the exception of multirows
+// (or multicells with more than one row) which can not be written before
+// knowing the rows that come next.
+//
+// In case it is not possible to process the given arguments or they do not fit
+// in the width of the columns, an error is returned and nothing is written
+func (s *StreamWriter) AddRow(cells ...any) error {
+
+	// rows can not span over other rows
+	for _, cell := range cells {
+		if m, ok := cell.(multicell); ok && m.getNbRows() > 1 {
+			return errors.New("Multicells spanning several rows can not be written to a stream")
+		}
+	}
+
+	// add the row to a new table and write it
+	t := s.newTable()
+	if err := t.AddRow(cells...); err != nil {
+		return err
+	}
+	return s.render(t)
+}
+
+// Add a single horizontal rule to the stream writer and write it immediately.
+// Columns are given as in Table.AddSingleRule
+func (s *StreamWriter) AddSingleRule(cols ...int) error {
+
+	t := s.newTable()
+	if err := t.AddSingleRule(cols...); err != nil {
+		return err
+	}
+	return s.render(t)
+}
+
+// Add a double horizontal rule to the stream writer and write it immediately.
+// Columns are given as in Table.AddDoubleRule
+func (s *StreamWriter) AddDoubleRule(cols ...int) error {
+
+	t := s.newTable()
+	if err := t.AddDoubleRule(cols...); err != nil {
+		return err
+	}
+	return s.render(t)
+}
+
+// Add a thick horizontal rule to the stream writer and write it immediately.
+// Columns are given as in Table.AddThickRule
+func (s *StreamWriter) AddThickRule(cols ...int) error {
+
+	t := s.newTable()
+	if err := t.AddThickRule(cols...); err != nil {
+		return err
+	}
+	return s.render(t)
+}
+
+// SetTheme sets the theme used to draw the horizontal rules, vertical
+// separators and junctions of the rows written from now on
+func (s *StreamWriter) SetTheme(theme Theme) {
+	s.theme = theme
+}
+
+// Flush writes the last horizontal rule, which is kept pending until the line
+// below it is known. It should be invoked once all rows and rules have been
+// added to the stream writer
+func (s *StreamWriter) Flush() error {
+
+	// if there is no line pending, then do nothing
+	if !s.pending {
+		return nil
+	}
+
+	// otherwise, write it
+	if _, err := fmt.Fprintln(s.writer, strings.Map(s.theme.translate, s.next)); err != nil {
+		return err
+	}
+	s.last, s.started, s.pending = s.next, true, false
+	return nil
+}
+
+// Local Variables:
+// mode:go
+// fill-column:80
+// End:
@@ -0,0 +1,151 @@
+// -*- coding: utf-8 -*-
+// stream_test.go
+// -----------------------------------------------------------------------------
+//
+// Started on <sáb 17-10-2026 01:22:58 (1792200178)>
+//
+
+package table
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestStreamWriter(t *testing.T) {
+	type args struct {
+		colspec string
+		widths  []int
+	}
+	tests := []struct {
+		name string
+		args args
+		add  func(s *StreamWriter) error
+		want string
+	}{
+
+		// columns with a paragraph alignment
+		{name: "paragraphs",
+			args: args{colspec: "| L{6} | R{4} |"},
+			add: func(s *StreamWriter) error {
+				s.AddThickRule()
+				s.AddRow("Job", "%")
+				s.AddSingleRule()
+				s.AddRow("build", 100)
+				s.AddRow("test suite", 45)
+				return s.AddThickRule()
+			},
+			want: `┍━━━━━━━━┯━━━━━━┑
+│ Job    │    % │
+├────────┼──────┤
+│ build  │  100 │
+│ test   │   45 │
+│ suite  │      │
+┕━━━━━━━━┷━━━━━━┙
+`},
+
+		// columns with explicit widths, multicolumns and partial rules
+		{name: "explicit widths",
+			args: args{colspec: "| l || c | r |", widths: []int{5, 3, 3}},
+			add: func(s *StreamWriter) error {
+				s.AddRow("a", "b", "c")
+				s.AddSingleRule(1, 3)
+				s.AddRow(Multicolumn(2, "| l", "merged"), "d")
+				return s.AddDoubleRule()
+			},
+			want: `│ a     ║  b  │   c │
+│       ╙─────┼─────┤
+│ merged      │   d │
+╘═════════════╧═════╛
+`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var sb strings.Builder
+			s, err := NewStreamWriter(&sb, tt.args.widths, tt.args.colspec)
+			if err != nil {
+				t.Fatalf("NewStreamWriter() = %v", err)
+			}
+			if err := tt.add(s); err != nil {
+				t.Fatalf("StreamWriter.Add*() = %v", err)
+			}
+			if err := s.Flush(); err != nil {
+				t.Fatalf("StreamWriter.Flush() = %v", err)
+			}
+			if got := sb.String(); got != tt.want {
+				t.Errorf("StreamWriter =\n%v\nwant\n%v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewStreamWriter(t *testing.T) {
+	type args struct {
+		colspec string
+		widths  []int
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{args: args{colspec: "l c r"},
+			wantErr: true},
+		{args: args{colspec: "l c r", widths: []int{1, 2}},
+			wantErr: true},
+		{args: args{colspec: "l c r", widths: []int{1, 2, 3, 4}},
+			wantErr: true},
+		{args: args{colspec: "l c r", widths: []int{1, 2, -3}},
+			wantErr: true},
+		{args: args{colspec: "l c r", widths: []int{1, 2, 3}},
+			wantErr: false},
+		{args: args{colspec: "p{10} c r", widths: []int{0, 2, 3}},
+			wantErr: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var sb strings.Builder
+			if _, err := NewStreamWriter(&sb, tt.args.widths, tt.args.colspec); (err != nil) != tt.wantErr {
+				t.Errorf("NewStreamWriter() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestStreamWriter_AddRow(t *testing.T) {
+
+	// multirows can not be written to a stream and neither can multicolumns
+	// which exceed the width of the columns they span
+	var sb strings.Builder
+	s, _ := NewStreamWriter(&sb, nil, "| L{3} | L{3} |")
+	if err := s.AddRow(Multirow(2, "c", "a")); err == nil {
+		t.Errorf("StreamWriter.AddRow() accepted a multirow")
+	}
+	if err := s.AddRow(Multicolumn(2, "| c |", "too long to fit")); err == nil {
+		t.Errorf("StreamWriter.AddRow() accepted a multicolumn exceeding the width of its columns")
+	}
+	if err := s.Flush(); err != nil || sb.String() != "" {
+		t.Errorf("StreamWriter = '%v', want ''", sb.String())
+	}
+}
+
+func TestStreamWriter_Write(t *testing.T) {
+
+	// lines of data are written immediately, whereas horizontal rules are kept
+	// pending until the line below them is known
+	var sb strings.Builder
+	s, _ := NewStreamWriter(&sb, []int{3, 3}, "| l | r |")
+	s.AddSingleRule()
+	if got := sb.String(); got != "" {
+		t.Errorf("StreamWriter = '%v', want ''", got)
+	}
+	s.AddRow("a", "b")
+	if got, want := sb.String(), "┌─────┬─────┐\n│ a   │   b │\n"; got != want {
+		t.Errorf("StreamWriter =\n%v\nwant\n%v", got, want)
+	}
+	s.AddSingleRule()
+	s.Flush()
+	if got, want := sb.String(), "┌─────┬─────┐\n│ a   │   b │\n└─────┴─────┘\n"; got != want {
+		t.Errorf("StreamWriter =\n%v\nwant\n%v", got, want)
+	}
+}
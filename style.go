@@ -32,8 +32,8 @@ func newStyle(spec string) (*style, error) {
 		return &style{}, errors.New("invalid style specification")
 	}
 
-	// now, check for the special case of the qualifiers 'p/C/L/R' which accept
-	// a numerical argument
+	// now, check for the special case of the qualifiers 'p/C/L/R' (and also
+	// 'c/l/r' when truncating their contents) which accept a numerical argument
 	re = regexp.MustCompile(pRegex)
 	pmatch := re.FindStringIndex(spec)
 	if pmatch == nil {
@@ -53,3 +53,28 @@ func newStyle(spec string) (*style, error) {
 	return &style{alignment: spec[0],
 		arg: arg}, nil
 }
+
+// Methods
+// ----------------------------------------------------------------------------
+
+// return true if and only if this style is a paragraph alignment (p, C, L, R),
+// i.e., if the width of the contents is bounded and they are split across
+// various lines if needed
+func (s style) isParagraph() bool {
+	return s.alignment == 'p' ||
+		s.alignment == 'C' ||
+		s.alignment == 'L' ||
+		s.alignment == 'R'
+}
+
+// return true if and only if this style aligns numbers on the decimal point
+func (s style) isDecimal() bool {
+	return s.alignment == 'd'
+}
+
+// return true if and only if this style truncates its contents (c{N}, l{N},
+// r{N}), i.e., if the width of the contents is bounded and they are shown in a
+// single line which is truncated if needed
+func (s style) isTruncated() bool {
+	return (s.alignment == 'c' || s.alignment == 'l' || s.alignment == 'r') && s.arg > 0
+}
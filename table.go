@@ -10,9 +10,10 @@
 // strongly based on the definition of tables in LaTeX but extends its
 // functionality in various ways through a very simple interface
 //
-// It honours UTF-8 characters, ANSI color escape sequences, full/partial
-// horizontal rules, and a wide variety of vertical and horizontal alignment
-// options.
+// It honours UTF-8 characters (including East Asian wide characters, emojis and
+// combining marks, which are measured by the number of cells they take in a
+// terminal), ANSI color escape sequences, full/partial horizontal rules, and a
+// wide variety of vertical and horizontal alignment options.
 //
 // Remarkably, it prints any stringer and as tables are stringers, tables can be
 // nested to any degree.
@@ -22,7 +23,6 @@ import (
 	"errors"
 	"fmt"
 	"log"
-	"regexp"
 	"strings"
 )
 
@@ -53,6 +53,15 @@ import (
 //     exceed NUMBER columns and the contents are ragged left/centered/ragged
 //     right respectively
 //
+//  6. 'l{NUMBER}'/'c{NUMBER}'/'r{NUMBER}': the width of the column is fixed to
+//     NUMBER positions and the contents are shown in a single line which is
+//     truncated with an ellipsis if needed (see SetEllipsis)
+//
+//  7. 'd': numbers are aligned on the decimal point (or, if they have none, on
+//     the end of their integer part). Numbers can be signed, use commas as
+//     thousands separators and have an exponent. Any other contents are
+//     centered
+//
 // In addition, the column specification might contain other characters which are
 // then added to the contents as well.
 //
@@ -74,7 +83,7 @@ import (
 //
 // NewTable returns a pointer to a table which can then be used to access its
 // services. In case either the column or row specification could not be
-// processed it returns an error.
+// processed it returns a *SpecError with the location of the offending token.
 func NewTable(spec ...string) (*Table, error) {
 
 	// error-checking
@@ -93,21 +102,9 @@ func NewTable(spec ...string) (*Table, error) {
 		rowspec = spec[1]
 	}
 
-	// first things first, verify that the given column specification is
-	// syntactically correct
-	re := regexp.MustCompile(colSpecRegexAll)
-	if !re.MatchString(colspec) {
-		return &Table{}, errors.New("invalid column specification")
-	}
-
-	// Now, make the appropriate substitutions in case the user used ANSI
-	// characters for specifying vertical delimiters
-	colspec = strings.ReplaceAll(strings.ReplaceAll(strings.ReplaceAll(colspec,
-		"|||", "┃"),
-		"||", "║"),
-		"|", "│")
-
-	// process the column specification given
+	// process the column specification given. Note that ASCII vertical
+	// separators are substituted afterwards so that errors are located in the
+	// specification given by the user
 	columns, err := getColumns(colspec)
 	if err != nil {
 		return &Table{}, err
@@ -128,8 +125,7 @@ func NewTable(spec ...string) (*Table, error) {
 		// than the number of columns given in the column specification, if any
 		// was given
 		if len(vertFmt) > 0 && len(vertFmt) > len(columns) {
-			return &Table{}, fmt.Errorf("The number of columns given in the row specification (%v) must be less or equal than %v, the number of columns given in the column specification",
-				len(vertFmt), len(columns))
+			return &Table{}, newSpecError("row", rowspec, len(columns), nil)
 		}
 		for j, jstyle := range vertFmt {
 			columns[j].vformat = jstyle
@@ -380,12 +376,22 @@ func (t *Table) distributeAllRows() {
 	}
 }
 
-// return true if there is a multicell in the given column which reaches the
-// specified row, and false otherwise
+// return true if the irow-th logical row of this table is a horizontal rule
+// and false otherwise
+func (t *Table) isRule(irow int) bool {
+
+	// horizontal rules span all columns so that any can be used to verify
+	// whether this row is a horizontal rule or not
+	_, ok := t.cells[irow][0].(hrule)
+	return ok
+}
+
+// return true if there is a multicell in the given column which starts in a
+// previous row and reaches the specified row, and false otherwise
 func (t *Table) hasMulticell(irow, jcol int) bool {
 
-	// for all rows in this column
-	for i := 0; i < len(t.rows); i++ {
+	// for all previous rows in this column
+	for i := 0; i < irow && i < len(t.rows); i++ {
 
 		// if a multicell is found which reaches the given row
 		if m, ok := t.cells[i][jcol].(multicell); ok && m.getRowInit()+m.getNbRows() > irow {
@@ -399,31 +405,146 @@ func (t *Table) hasMulticell(irow, jcol int) bool {
 	return false
 }
 
-// -- Public
+// return a pointer to the multicell which takes the given location or nil if
+// there is none. Note that a multicell takes all locations from its initial
+// row and column to the number of rows and columns it spans
+func (t *Table) getMulticell(irow, jcol int) *multicell {
 
-// Add a new line of data to the bottom of the column. This function accepts an
-// arbitrary number of arguments. The content shown on the table is the output
-// of a Sprintf operation over each argument
-//
-// If the number of arguments is less than the number of columns, the last cells
-// are left empty, unless no argument is given at all in which case no row is
-// inserted. Finally, if the number of elements given exceeds the number of
-// columns an error is immediately issued
-func (t *Table) AddRow(cells ...any) error {
+	// for all rows until the given one, and all columns until the given one
+	for i := 0; i <= irow && i < len(t.cells); i++ {
+		for j := 0; j <= jcol && j < len(t.cells[i]); j++ {
+
+			// if a multicell is found which reaches the given location
+			if m, ok := t.cells[i][j].(multicell); ok &&
+				m.getRowInit()+m.getNbRows() > irow &&
+				m.getColumnInit()+m.getNbColumns() > jcol {
+
+				// then return it
+				return &m
+			}
+		}
+	}
+
+	// if no multicell is found which reaches the given location, then return
+	// nil
+	return nil
+}
+
+// compute the final width of all columns and height of all rows before drawing
+// the table. In case the table exceeds its maximum width, its columns are
+// shrunk over a copy of them so that the table is not modified
+func (t *Table) layout() {
+
+	// First things first, traverse all muulticells in this table and
+	// re-distribute the width of columns (either those of the table or those in
+	// the multicell) and the height of all rows
+	t.distributeAllColumns()
+
+	// in case the table exceeds its maximum width, then shrink its columns
+	if t.maxwidth > 0 && t.getColumnsWidth(0, len(t.columns)) > t.maxwidth {
+		t.columns = append([]column(nil), t.columns...)
+		t.rows = append([]row(nil), t.rows...)
+		t.fitColumns(t.maxwidth)
+	}
+	t.distributeAllRows()
+}
+
+// record the given error if no other error has been found before. It is used by
+// the methods that process and format cells, which can not return errors
+func (t *Table) setError(err error) {
+	if t.err == nil {
+		t.err = err
+	}
+}
+
+// return an error if this table can not be drawn, e.g., because a multicell
+// spans more rows than those added to the table
+func (t *Table) verify() error {
+
+	for i := range t.cells {
+		for j := range t.cells[i] {
+			if m, ok := t.cells[i][j].(multicell); ok && m.getRowInit()+m.getNbRows() > len(t.rows) {
+				return fmt.Errorf("The multicell in location (%v, %v) spans %v rows but the table has only %v rows",
+					i, j, m.getNbRows(), len(t.rows))
+			}
+		}
+	}
+	return nil
+}
+
+// return the ellipsis used to show that the contents of a cell have been
+// truncated
+func (t *Table) getEllipsis() string {
+	if t.ellipsis == nil {
+		return default_ellipsis
+	}
+	return *t.ellipsis
+}
+
+// update the width of the jcol-th column so that it can show all the given
+// physical lines, each one surrounded by the given number of blank characters.
+// If decimal is true, then the lines are aligned on the decimal point
+func (t *Table) updateColumnWidth(jcol int, lines []string, padding int, decimal bool) {
+
+	// aliasing
+	col := &t.columns[jcol]
+
+	// If this column is a paragraph (of any type) or its contents are
+	// truncated then use the width defined
+	if col.hformat.isParagraph() || col.hformat.isTruncated() {
+
+		// Importantly, the width of this column should be modified if and
+		// only if it is less than the argument given in the paragraph
+		// argument. The reason is that the width of this column might have
+		// been increased (e.g., because it is part of a multicell) so that
+		// it should not be modified now!!
+		if col.width < col.hformat.arg {
+			col.width = col.hformat.arg
+		}
+
+		// however, paragraphs split their contents in lines with at least
+		// one glyph, which might be wider than the column
+		if col.hformat.isParagraph() {
+			for _, line := range lines {
+				col.width = max[int](col.width, countPrintableRuneInString(line)+padding)
+			}
+		}
+		return
+	}
+
+	// Otherwise, take the maximum width among all lines
+	for _, line := range lines {
+		col.width = max[int](col.width, countPrintableRuneInString(line)+padding)
+
+		// in case numbers are aligned on the decimal point, update the number
+		// of cells taken by their integer part and the rest, and make sure
+		// they all fit in the column
+		if before, after, ok := splitDecimal(line); ok && decimal {
+			col.before = max[int](col.before, before)
+			col.after = max[int](col.after, after)
+			col.width = max[int](col.width, col.before+col.after+padding)
+		}
+	}
+}
+
+// return the formatters of a new row of data to be inserted in the irow-th
+// location with the given cells, and the number of columns whose contents were
+// given. Multicells are placed in their location, and the locations taken by
+// multicells inserted in previous rows are skipped. In case the cells can not
+// be inserted an error is returned
+func (t *Table) newRow(irow int, cells ...any) ([]formatter, int, error) {
 
 	// if the number of elements given exceeds the number of columns then
 	// immediately raised an error
 	if t.GetNbColumns() < len(cells) {
-		return fmt.Errorf("The number of elements given (%v) exceeds the number of columns (%v)",
+		return nil, 0, fmt.Errorf("The number of elements given (%v) exceeds the number of columns (%v)",
 			len(cells), t.GetNbColumns())
 	}
 
-	// otherwise, process all cells given and add them to the table as cells
-	// which can be formatted. 'j' is the (logical) column index and height is
-	// the number of physical rows required to draw this row, whereas idx is the
-	// index of the next cell to process used in the loop that iterates over
-	// them
-	var j, height int
+	// otherwise, process all cells given and create the cells which can be
+	// formatted. 'j' is the (logical) column index, whereas idx is the index
+	// of the next cell to process used in the loop that iterates over them
+	var j int
 	icells := make([]formatter, len(t.columns))
 	for idx := 0; idx < t.GetNbColumns() && idx < len(cells); idx++ {
 
@@ -431,7 +552,7 @@ func (t *Table) AddRow(cells ...any) error {
 		// multicell) make sure that the j-th column is not already occupied by
 		// a multicell inserted previously. This allows the user to provide only
 		// the contents to be shown
-		for t.hasMulticell(len(t.rows), j) {
+		for t.hasMulticell(irow, j) {
 			j++
 		}
 
@@ -439,7 +560,7 @@ func (t *Table) AddRow(cells ...any) error {
 		// traversed when considering multicells previously inserted), then
 		// return an error
 		if j >= t.GetNbColumns() {
-			return errors.New("Invalid multicell specification. The number of available columns has been execeeded!")
+			return nil, 0, errors.New("Invalid multicell specification. The number of available columns has been execeeded!")
 		}
 
 		// depending upon the type of item
@@ -447,86 +568,34 @@ func (t *Table) AddRow(cells ...any) error {
 
 		case multicell:
 
-			// if this item is a multicell, verify first that it does not go
-			// beyond bounds
+			// if this item is a multicell, verify first that it was properly
+			// created and that it does not go beyond bounds
 			m := cells[idx].(multicell)
-			if j+m.nbcolumns > t.GetNbColumns() {
-				return errors.New("Invalid multicell specification. The number of available columns has been execeeded!")
+			if err := m.verify(); err != nil {
+				return nil, 0, err
 			}
-
-			// record this multicell as an ordinary formatter, copy the initial
-			// column and row where the multicell starts and register this
-			// multicell in the table.
-			m.jinit, m.iinit = j, len(t.rows)
-			icells[j] = m
-
-			// Modify the column/row specification of this multicell if
-			// necessary
-			switch m.mtype {
-
-			case multicolumn_t:
-
-				// In case this is a multicolumn then make sure to use the row
-				// specification given to the table
-				m.table.columns[0].vformat = t.columns[j].vformat
-
-			case multirow_t:
-
-				// In case this is a multirow then make sure to use the column
-				// specification given to the table
-				m.table.columns[0].sep = t.columns[j].sep
-				m.table.columns[0].hformat = t.columns[j].hformat
+			if j+m.nbcolumns > t.GetNbColumns() {
+				return nil, 0, errors.New("Invalid multicell specification. The number of available columns has been execeeded!")
 			}
 
-			// otherwise, process this multicell to know its height. Note that
-			// multicells have an arbitrary number of rows. Because we are
-			// interested in the height of this logical row, the number of lines
-			// taken by the multicell has to be divided by the number of logical
-			// rows it occupies. Observe that this row is necessarily added to
-			// the bottom of the table
-			contents := m.Process(t, len(t.rows), j)
-			height = max[int](height, len(contents)/m.nbrows)
+			// copy the initial column and row where the multicell starts,
+			// and modify its column/row specification if necessary
+			m.jinit, m.iinit = j, irow
+			t.adjustMulticell(m)
 
-			// finally, now move forward the number of columns
+			// record this multicell as an ordinary formatter and move forward
+			// the number of columns
+			icells[j] = m
 			j += m.nbcolumns
 
 		default:
 
-			// add the content of the j-th column with a string that represents it
-			icells[j] = content(fmt.Sprintf("%v", cells[idx]))
-
-			// process the contents of this cell, and update the number of physical
-			// rows required to show this line. Note that this row is added to the
-			// bottom of the table
-			contents := icells[j].Process(t, len(t.rows), j)
-			height = max[int](height, len(contents))
-
-			// in addition update the number of physical columns required to
-			// draw this cell.
-			//
-			// If this column is a paragraph (of any type) then use the width
-			// defined
-			if t.columns[j].hformat.alignment == 'p' ||
-				t.columns[j].hformat.alignment == 'C' ||
-				t.columns[j].hformat.alignment == 'L' ||
-				t.columns[j].hformat.alignment == 'R' {
-
-				// Importantly, the width of this column should be modified if and
-				// only if it is less than the argument given in the paragraph
-				// argument. The reason is that the width of this column might have
-				// been increased (e.g., because it is part of a multicell) so that
-				// it should not be modified now!!
-				if t.columns[j].width < t.columns[j].hformat.arg {
-					t.columns[j].width = t.columns[j].hformat.arg
-				}
-			} else {
-
-				// Otherwise, take the maximum width among all columns
-				for _, line := range contents {
-					t.columns[j].width = max[int](t.columns[j].width,
-						countPrintableRuneInString(string(line.(content))))
-				}
+			// any other item is stored as a formatter of its own
+			item, err := t.newFormatter(cells[idx], j)
+			if err != nil {
+				return nil, 0, err
 			}
+			icells[j] = item
 
 			// and move to the next column
 			j++
@@ -536,87 +605,120 @@ func (t *Table) AddRow(cells ...any) error {
 	// now, if not all columns were given then automatically add empty cells.
 	// Note that an empty cell is added also to the last column even if it
 	// contains no data
+	given := j
 	for ; j < len(t.columns); j++ {
 		icells[j] = content(horizontal_empty)
 	}
 
-	// add these cells to this table, along with the number of physical rows
-	// required to draw it
-	t.cells = append(t.cells, icells)
-	t.rows = append(t.rows, row{height: height})
-
-	// and exit with no error
-	return nil
+	return icells, given, nil
 }
 
-// Add a single horizontal rule to the table from a start column to and end
-// column. Any number of pairs (start, end) can be given. If no column is given,
-// the horizontal rule takes the entire width of the table.
-//
-// In case it is not possible to process the given specification an informative
-// error is returned
-func (t *Table) AddSingleRule(cols ...int) error {
+// modify the column/row specification of the given multicell, if necessary, to
+// use the specification of the column where it starts
+func (t *Table) adjustMulticell(m multicell) {
 
-	return t.addRule(hrule(horizontal_single), cols...)
-}
+	switch m.mtype {
 
-// Add a double horizontal rule to the table from a start column to and end
-// column. Any number of pairs (start, end) can be given. If no column is given,
-// the horizontal rule takes the entire width of the table.
-//
-// In case it is not possible to process the given specification an informative
-// error is returned
-func (t *Table) AddDoubleRule(cols ...int) error {
+	case multicolumn_t:
 
-	return t.addRule(hrule(horizontal_double), cols...)
-}
+		// In case this is a multicolumn then make sure to use the row
+		// specification given to the table
+		m.table.columns[0].vformat = t.columns[m.jinit].vformat
 
-// Add a thick horizontal rule to the table from a start column to and end
-// column. Any number of pairs (start, end) can be given. If no column is given,
-// the horizontal rule takes the entire width of the table.
-//
-// In case it is not possible to process the given specification an informative
-// error is returned
-func (t *Table) AddThickRule(cols ...int) error {
+	case multirow_t:
 
-	return t.addRule(hrule(horizontal_thick), cols...)
+		// In case this is a multirow then make sure to use the column
+		// specification given to the table
+		m.table.columns[0].sep = t.columns[m.jinit].sep
+		m.table.columns[0].hformat = t.columns[m.jinit].hformat
+	}
 }
 
-// Return the number of logical columns in a table which contain data.
-func (t *Table) GetNbColumns() int {
+// return the formatter used to show the given item in the jcol-th column, which
+// can not be a multicell: cells are shown with their own format, and any other
+// item is shown with a string that represents it. In both cases, the original
+// value is retained and its text is computed with the formatter of the column
+func (t *Table) newFormatter(item any, jcol int) (formatter, error) {
 
-	// if this table comes with a last column with no contents, then discard it
-	if len(t.columns) > 0 && t.columns[len(t.columns)-1].hformat.alignment == 0 {
-		return len(t.columns) - 1
+	if c, ok := item.(Cell); ok {
+		result, err := newCell(c)
+		if err != nil {
+			return nil, err
+		}
+		result.text = t.formatValue(jcol, c.Value)
+		return result, nil
 	}
-
-	// otherwise, return the number of columns defined
-	return len(t.columns)
+	return value{data: item, text: t.formatValue(jcol, item)}, nil
 }
 
-// Return the number of logical rows in a table, i.e., the number of rows given
-// by the user. The number of logical rows includes both horizontal separators
-// and data lines
-func (t *Table) GetNbRows() int {
-	return len(t.cells)
-}
+// return the number of physical rows required to draw the given cells of the
+// irow-th row of data, and update the width of all columns so that they can
+// show them. Only the cells whose contents were given should be passed
+func (t *Table) measureRow(irow int, icells []formatter) (height int) {
 
-func (t *Table) Describe() {
+	for j, item := range icells {
 
-	log.Printf(" # Columns: %d\n", len(t.columns))
-	log.Printf(" # Rows   : %d\n", len(t.columns))
+		// values are measured as the contents used to show them
+		if v, ok := item.(value); ok {
+			item = v.text
+		}
+
+		// depending upon the type of item
+		switch c := item.(type) {
+
+		case multicell:
 
+			// process this multicell to know its height. Note that multicells
+			// have an arbitrary number of rows. Because we are interested in
+			// the height of this logical row, the number of lines taken by the
+			// multicell has to be divided by the number of logical rows it
+			// occupies
+			contents := c.Process(t, irow, j)
+			height = max[int](height, len(contents)/c.nbrows)
+
+		case cell:
+
+			// cells are processed as ordinary contents but with their own
+			// format, so that the space they require is computed in the same
+			// way
+			contents := c.Process(t, irow, j)
+			height = max[int](height, len(contents))
+
+			var lines []string
+			for _, line := range contents {
+				lines = append(lines, string(line.(cell).text))
+			}
+			t.updateColumnWidth(j, lines, c.padleft+c.padright, c.getColumn(t, j).hformat.isDecimal())
+
+		case content:
+
+			// process the contents of this cell, and update the number of
+			// physical rows required to show this line
+			contents := c.Process(t, irow, j)
+			height = max[int](height, len(contents))
+
+			// in addition update the number of physical columns required to
+			// draw this cell
+			var lines []string
+			for _, line := range contents {
+				lines = append(lines, string(line.(content)))
+			}
+			t.updateColumnWidth(j, lines, 0, t.columns[j].hformat.isDecimal())
+		}
+	}
+	return
 }
 
-// Tables are stringers and thus they provide a method to conveniently transform
-// their contents into a string
-func (t Table) String() string {
+// return all the physical lines used to draw this table, without splitters,
+// along with the first error found, if any. Because the width of all columns
+// and the height of all rows are computed here, the physical lines of every
+// logical row can be located with the height of all the rows before it
+func (t *Table) render() ([]string, error) {
 
-	// First things first, traverse all muulticells in this table and
-	// re-distribute the width of columns (either those of the table or those in
-	// the multicell) and the height of all rows
-	t.distributeAllColumns()
-	t.distributeAllRows()
+	t.err = nil
+
+	// compute the width of all columns and the height of all rows
+	t.layout()
 
 	// Because of the presence of multicells, each line can print at once an
 	// arbitrary number of columns and rows. Hence, it is required to keep track
@@ -652,16 +754,16 @@ func (t Table) String() string {
 			if nbcolumns[i] <= j && nbrows[j] <= i {
 
 				// Process the contents of this cell
-				contents := t.cells[i][j].Process(&t, i, j)
+				contents := t.cells[i][j].Process(t, i, j)
 
 				// and now for each physical row of this line
 				for line := 0; line < len(contents); line++ {
 
 					// add this line to the output
 					if idx >= len(output) {
-						output = append(output, fmt.Sprintf("%v", contents[line].Format(&t, i, j)))
+						output = append(output, fmt.Sprintf("%v", contents[line].Format(t, i, j)))
 					} else {
-						output[idx] += fmt.Sprintf("%v", contents[line].Format(&t, i, j))
+						output[idx] += fmt.Sprintf("%v", contents[line].Format(t, i, j))
 					}
 
 					// and move to the next physical line
@@ -697,10 +799,140 @@ func (t Table) String() string {
 		}
 	}
 
-	// insert all splitters
+	return output, t.err
+}
+
+// -- Public
+
+// Add a new line of data to the bottom of the column. This function accepts an
+// arbitrary number of arguments. The content shown on the table is the output
+// of a Sprintf operation over each argument, or the text returned by the
+// formatter of its column (see SetColumnFormatter), and the original arguments
+// are retained as well. Arguments of type Cell are shown with their own format,
+// which overrides that of their column
+//
+// If the number of arguments is less than the number of columns, the last cells
+// are left empty, unless no argument is given at all in which case no row is
+// inserted. Finally, if the number of elements given exceeds the number of
+// columns an error is immediately issued
+func (t *Table) AddRow(cells ...any) error {
+
+	// create the cells of the new row, which is necessarily added to the
+	// bottom of the table
+	icells, given, err := t.newRow(len(t.rows), cells...)
+	if err != nil {
+		return err
+	}
+
+	// add these cells to this table, along with the number of physical rows
+	// required to draw it
+	height := t.measureRow(len(t.rows), icells[:given])
+	t.cells = append(t.cells, icells)
+	t.rows = append(t.rows, row{height: height, given: given})
+
+	// and exit with no error
+	return nil
+}
+
+// Add a single horizontal rule to the table from a start column to and end
+// column. Any number of pairs (start, end) can be given. If no column is given,
+// the horizontal rule takes the entire width of the table.
+//
+// In case it is not possible to process the given specification an informative
+// error is returned
+func (t *Table) AddSingleRule(cols ...int) error {
+
+	return t.addRule(hrule(horizontal_single), cols...)
+}
+
+// Add a double horizontal rule to the table from a start column to and end
+// column. Any number of pairs (start, end) can be given. If no column is given,
+// the horizontal rule takes the entire width of the table.
+//
+// In case it is not possible to process the given specification an informative
+// error is returned
+func (t *Table) AddDoubleRule(cols ...int) error {
+
+	return t.addRule(hrule(horizontal_double), cols...)
+}
+
+// Add a thick horizontal rule to the table from a start column to and end
+// column. Any number of pairs (start, end) can be given. If no column is given,
+// the horizontal rule takes the entire width of the table.
+//
+// In case it is not possible to process the given specification an informative
+// error is returned
+func (t *Table) AddThickRule(cols ...int) error {
+
+	return t.addRule(hrule(horizontal_thick), cols...)
+}
+
+// SetEllipsis sets the string shown at the end of the contents of those cells
+// which are truncated (in columns c{N}, l{N} and r{N}). By default, the
+// ellipsis '…' is used. An empty string means that contents are truncated with
+// no ellipsis.
+func (t *Table) SetEllipsis(ellipsis string) {
+	t.ellipsis = &ellipsis
+}
+
+// Return the number of logical columns in a table which contain data.
+func (t *Table) GetNbColumns() int {
+
+	// if this table comes with a last column with no contents, then discard it
+	if len(t.columns) > 0 && t.columns[len(t.columns)-1].hformat.alignment == 0 {
+		return len(t.columns) - 1
+	}
+
+	// otherwise, return the number of columns defined
+	return len(t.columns)
+}
+
+// Return the number of logical rows in a table, i.e., the number of rows given
+// by the user. The number of logical rows includes both horizontal separators
+// and data lines
+func (t *Table) GetNbRows() int {
+	return len(t.cells)
+}
+
+func (t *Table) Describe() {
+
+	log.Printf(" # Columns: %d\n", len(t.columns))
+	log.Printf(" # Rows   : %d\n", len(t.columns))
+
+}
+
+// Tables are stringers and thus they provide a method to conveniently transform
+// their contents into a string. In case the table can not be drawn, an empty
+// string is returned. Use Render to get also the error found, if any
+func (t Table) String() string {
+	output, _ := t.Render()
+	return output
+}
+
+// Render returns a string with the contents of the table, exactly as String
+// does, and an error if the table could not be drawn, e.g., because a multirow
+// spans more rows than those added to the table. In case of error, the string
+// returned might be partially drawn, or empty if nothing could be drawn at all
+func (t Table) Render() (string, error) {
+
+	// first, verify that the table can be drawn at all
+	if err := t.verify(); err != nil {
+		return "", err
+	}
+
+	// hidden columns are drawn by removing them from a copy of the table
+	if t.hasHiddenColumns() {
+		return t.getVisibleTable().Render()
+	}
+
+	// draw all physical lines, insert all splitters and draw them with the
+	// runes of the theme of the table
+	output, err := t.render()
+	output = t.theme.normalizeLines(output)
 	addSplitters(output)
+	output = t.theme.translateLines(output)
 
 	// and return the concatenation of all strings in the output string
-	// separated by a newline
-	return strings.Join(output, "\n")
+	// separated by a newline along with the first error found, if any
+	return strings.Join(output, "\n"), err
 }
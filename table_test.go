@@ -34,19 +34,19 @@ func TestNewTable(t *testing.T) {
 		// invalid column specifications
 		{args: args{"", ""},
 			wantTable: &Table{},
-			wantError: errors.New("invalid column specification")},
+			wantError: errors.New("invalid column specification: unexpected end of specification at offset 0, expected one of l, c, r, d, L{N}, C{N}, R{N}, p{N}, l{N}, c{N}, r{N}")},
 
 		{args: args{"|", ""},
 			wantTable: &Table{},
-			wantError: errors.New("invalid column specification")},
+			wantError: errors.New("invalid column specification: unexpected end of specification at offset 1, expected one of l, c, r, d, L{N}, C{N}, R{N}, p{N}, l{N}, c{N}, r{N}")},
 
 		{args: args{"c", "bb"},
 			wantTable: &Table{},
-			wantError: errors.New("The number of columns given in the row specification (2) must be less or equal than 1, the number of columns given in the column specification")},
+			wantError: errors.New("invalid row specification: unexpected 'b' at offset 1, expected the end of the specification")},
 
 		{args: args{"c", "x"},
 			wantTable: &Table{},
-			wantError: errors.New("'x' is an incorrect vertical format")},
+			wantError: errors.New("invalid row specification: unexpected 'x' at offset 0, expected one of t, c, b")},
 
 		// correct column specifications
 
@@ -341,6 +341,107 @@ func TestTable_GetNbColumns(t *testing.T) {
 	}
 }
 
+func TestTable_String(t *testing.T) {
+	tests := []struct {
+		name  string
+		table func() *Table
+		want  string
+	}{
+
+		// East Asian wide runes and emojis take two cells, whereas combining
+		// marks take none
+		{name: "wide runes",
+			table: func() *Table {
+				t, _ := NewTable("| l | c | r |")
+				t.AddThickRule()
+				t.AddRow("商品", "Price", "数量")
+				t.AddSingleRule()
+				t.AddRow("東京タワー", "¥1,200", 3)
+				t.AddRow("Ｔシャツ 👕", "¥2,500", 12)
+				t.AddRow("cafe\u0301", "€3", 1)
+				t.AddThickRule()
+				return t
+			},
+			want: `┍━━━━━━━━━━━━━┯━━━━━━━━┯━━━━━━┑
+│ 商品        │ Price  │ 数量 │
+├─────────────┼────────┼──────┤
+│ 東京タワー  │ ¥1,200 │    3 │
+│ Ｔシャツ 👕 │ ¥2,500 │   12 │
+` + "│ cafe\u0301        │   €3   │    1 │" + `
+┕━━━━━━━━━━━━━┷━━━━━━━━┷━━━━━━┙`},
+
+		{name: "wide runes in paragraphs",
+			table: func() *Table {
+				t, _ := NewTable("| p{5} |")
+				t.AddSingleRule()
+				t.AddRow("東京タワー")
+				t.AddSingleRule()
+				return t
+			},
+			want: `┌───────┐
+│ 東京  │
+│ タワ  │
+│ ー    │
+└───────┘`},
+
+		// glyphs wider than paragraphs widen their column
+		{name: "wide runes in narrow paragraphs",
+			table: func() *Table {
+				t, _ := NewTable("|p{1}|L{1}|")
+				t.AddSingleRule()
+				t.AddRow("漢字x", "字y")
+				t.AddSingleRule()
+				return t
+			},
+			want: `┌──┬──┐
+│漢│字│
+│字│y │
+│x │  │
+└──┴──┘`},
+
+		// contents of truncated columns are shown in a single line
+		{name: "truncated columns",
+			table: func() *Table {
+				t, _ := NewTable("| l{10} | r{6} | c{5} |")
+				t.AddRow("A rather long description", "two\nlines", "東京タワー")
+				t.AddRow("short", 123, "x")
+				t.SetEllipsis("...")
+				t.AddRow("A rather long description", 1234567, "xy")
+				return t
+			},
+			want: `│ A rathe... │ two... │ 東... │
+│ short      │    123 │   x   │
+│ A rathe... │ 123... │  xy   │`},
+
+		// numbers are aligned on the decimal point and any other contents are
+		// centered
+		{name: "decimal columns",
+			table: func() *Table {
+				t, _ := NewTable("| l | d | d |")
+				t.AddRow("Name", "Eccentricity", "Period")
+				t.AddSingleRule()
+				t.AddRow("Earth", 0.0167086, 365.256363004)
+				t.AddRow("Mars", -0.0934, "1,234.5")
+				t.AddRow("Big", "6.02e23", 42)
+				t.AddRow("None", "n/a", "")
+				return t
+			},
+			want: `│ Name  │ Eccentricity │     Period      │
+├───────┼──────────────┼─────────────────┤
+│ Earth │   0.0167086  │   365.256363004 │
+│ Mars  │  -0.0934     │ 1,234.5         │
+│ Big   │   6.02e23    │    42           │
+│ None  │     n/a      │                 │`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.table().String(); got != tt.want {
+				t.Errorf("Table.String() =\n%v\nwant\n%v", got, tt.want)
+			}
+		})
+	}
+}
+
 // ----------------------------------------------------------------------------
 // Examples
 // ----------------------------------------------------------------------------
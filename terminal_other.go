@@ -0,0 +1,20 @@
+// -*- coding: utf-8 -*-
+// terminal_other.go
+// -----------------------------------------------------------------------------
+//
+// Started on <sáb 17-10-2026 01:34:16 (1792200856)>
+//
+
+//go:build !(linux || darwin || freebsd)
+
+package table
+
+// the size of the terminal is unknown in this platform
+func getTerminalSize() int {
+	return 0
+}
+
+// Local Variables:
+// mode:go
+// fill-column:80
+// End:
@@ -0,0 +1,37 @@
+// -*- coding: utf-8 -*-
+// terminal_unix.go
+// -----------------------------------------------------------------------------
+//
+// Started on <sáb 17-10-2026 01:34:16 (1792200856)>
+//
+
+//go:build linux || darwin || freebsd
+
+package table
+
+import (
+	"syscall"
+	"unsafe"
+)
+
+// size of a terminal as returned by the ioctl TIOCGWINSZ
+type winsize struct {
+	rows, columns, xpixel, ypixel uint16
+}
+
+// return the number of columns of the terminal attached to the standard output
+// or 0 if it is not a terminal
+func getTerminalSize() int {
+
+	var ws winsize
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, uintptr(syscall.Stdout),
+		uintptr(syscall.TIOCGWINSZ), uintptr(unsafe.Pointer(&ws))); errno != 0 {
+		return 0
+	}
+	return int(ws.columns)
+}
+
+// Local Variables:
+// mode:go
+// fill-column:80
+// End:
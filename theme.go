@@ -0,0 +1,128 @@
+// -*- coding: utf-8 -*-
+// theme.go
+// -----------------------------------------------------------------------------
+//
+// Started on <sáb 17-10-2026 01:31:46 (1792200706)>
+//
+
+package table
+
+import "strings"
+
+// ----------------------------------------------------------------------------
+// Theme
+// ----------------------------------------------------------------------------
+
+// Methods
+// ----------------------------------------------------------------------------
+
+// -- Private
+
+// return the rune used in this theme to draw the given UTF-8 horizontal rule or
+// vertical separator, and whether the given rune is any of them at all
+func (th Theme) lookup(r rune) (rune, bool) {
+
+	var result rune
+	switch r {
+	case horizontal_single:
+		result = th.HorizontalSingle
+	case horizontal_double:
+		result = th.HorizontalDouble
+	case horizontal_thick:
+		result = th.HorizontalThick
+	case vertical_single:
+		result = th.VerticalSingle
+	case vertical_double:
+		result = th.VerticalDouble
+	case vertical_thick:
+		result = th.VerticalThick
+	default:
+		return r, false
+	}
+
+	// runes which are not given in this theme are drawn as in UTF-8
+	if result == none {
+		return r, true
+	}
+	return result, true
+}
+
+// return the UTF-8 horizontal rule or vertical separator used in this theme to
+// draw the given one, if it is drawn with another UTF-8 rule or separator
+// (e.g., single rules drawn as thick ones), so that junctions are computed with
+// it. Any other rune is returned as it is
+func (th Theme) normalize(r rune) rune {
+
+	if result, ok := th.lookup(r); ok {
+		if _, ok := ThemeUTF8.lookup(result); ok {
+			return result
+		}
+	}
+	return r
+}
+
+// return the rune used in this theme to draw the given rune, once it has been
+// normalized. Horizontal rules and vertical separators are drawn with their
+// own runes, any other UTF-8 box drawing character is drawn as a junction, and
+// any other rune is returned as it is
+func (th Theme) translate(r rune) rune {
+
+	if r < box_drawing_first || r > box_drawing_last {
+		return r
+	}
+	if result, ok := th.Junctions[r]; ok {
+		return result
+	}
+
+	// horizontal rules and vertical separators drawn with other UTF-8 rules
+	// and separators have been normalized already
+	if result, ok := th.lookup(r); ok {
+		if _, ok := ThemeUTF8.lookup(result); ok {
+			return r
+		}
+		return result
+	}
+	if th.Junction != none {
+		return th.Junction
+	}
+	return r
+}
+
+// return a copy of the given lines drawn with the UTF-8 box drawing characters
+// where all horizontal rules and vertical separators are normalized with this
+// theme
+func (th Theme) normalizeLines(lines []string) []string {
+
+	result := make([]string, len(lines))
+	for i, line := range lines {
+		result[i] = strings.Map(th.normalize, line)
+	}
+	return result
+}
+
+// return a copy of the given lines drawn with the UTF-8 box drawing characters
+// (once normalized) where all of them are substituted by the runes of this
+// theme
+func (th Theme) translateLines(lines []string) []string {
+
+	result := make([]string, len(lines))
+	for i, line := range lines {
+		result[i] = strings.Map(th.translate, line)
+	}
+	return result
+}
+
+// -- Public
+
+// SetTheme sets the theme used to draw the horizontal rules, vertical
+// separators and junctions of the table. By default, tables are drawn with
+// ThemeUTF8. Note that themes modify only the way tables are drawn and
+// therefore, they have no effect when exporting tables to other formats
+func (t *Table) SetTheme(theme Theme) {
+	t.theme = theme
+}
+
+// Local Variables:
+// mode:go
+// fill-column:80
+// End:
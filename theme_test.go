@@ -0,0 +1,138 @@
+// -*- coding: utf-8 -*-
+// theme_test.go
+// -----------------------------------------------------------------------------
+//
+// Started on <sáb 17-10-2026 01:31:46 (1792200706)>
+//
+
+package table
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTable_SetTheme(t *testing.T) {
+	tests := []struct {
+		name  string
+		theme Theme
+		want  string
+	}{
+		{name: "utf8",
+			theme: ThemeUTF8,
+			want: `┌──────╥───────┐
+│ Name ║ Value │
+╞══════╬═══════╡
+│ a    ║     1 │
+│     both     │
+┕━━━━━━━━━━━━━━┙`},
+		{name: "zero",
+			theme: Theme{},
+			want: `┌──────╥───────┐
+│ Name ║ Value │
+╞══════╬═══════╡
+│ a    ║     1 │
+│     both     │
+┕━━━━━━━━━━━━━━┙`},
+		{name: "ascii",
+			theme: ThemeASCII,
+			want: `+------+-------+
+| Name | Value |
++======+=======+
+| a    |     1 |
+|     both     |
++==============+`},
+		{name: "rounded",
+			theme: ThemeRounded,
+			want: `╭──────╥───────╮
+│ Name ║ Value │
+╞══════╬═══════╡
+│ a    ║     1 │
+│     both     │
+┕━━━━━━━━━━━━━━┙`},
+		{name: "heavy",
+			theme: ThemeHeavy,
+			want: `┏━━━━━━┳━━━━━━━┓
+┃ Name ║ Value ┃
+┣══════╬═══════┫
+┃ a    ║     1 ┃
+┃     both     ┃
+┗━━━━━━━━━━━━━━┛`},
+		{name: "no border",
+			theme: ThemeNoBorder,
+			want: `                
+  Name   Value  
+                
+  a          1  
+      both      
+                `},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tab, _ := NewTable("| l || r |")
+			tab.SetTheme(tt.theme)
+			tab.AddSingleRule()
+			tab.AddRow("Name", "Value")
+			tab.AddDoubleRule()
+			tab.AddRow("a", 1)
+			tab.AddRow(Multicolumn(2, "| c |", "both"))
+			tab.AddThickRule()
+			if got := tab.String(); got != tt.want {
+				t.Errorf("Table.String() =\n%v\nwant\n%v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStreamWriter_SetTheme(t *testing.T) {
+
+	var sb strings.Builder
+	s, _ := NewStreamWriter(&sb, []int{4, 0}, "| l | R{3} |")
+	s.SetTheme(ThemeASCII)
+	s.AddSingleRule()
+	s.AddRow("Job", "%")
+	s.AddSingleRule()
+	s.AddRow("a", 10)
+	s.AddSingleRule()
+	s.Flush()
+
+	want := `+------+-----+
+| Job  |   % |
++------+-----+
+| a    |  10 |
++------+-----+
+`
+	if got := sb.String(); got != want {
+		t.Errorf("StreamWriter =\n%v\nwant\n%v", got, want)
+	}
+}
+
+func TestTable_SetThemeContents(t *testing.T) {
+
+	// runes of the theme found in the contents of the cells are not taken as
+	// rules or separators
+	tab, _ := NewTable("| l | r |")
+	tab.SetTheme(ThemeASCII)
+	tab.AddSingleRule()
+	tab.AddRow("a|b", "-1")
+	tab.AddRow("c", "|")
+	tab.AddSingleRule()
+
+	want := `+-----+----+
+| a|b | -1 |
+| c   |  | |
++-----+----+`
+	if got := tab.String(); got != want {
+		t.Errorf("Table.String() =\n%v\nwant\n%v", got, want)
+	}
+	pages, err := tab.Paginate(3, 0)
+	if err != nil {
+		t.Fatalf("Paginate() = %v", err)
+	}
+	want = `+-----+----+
+| a|b | -1 |
++-----+----+`
+	if len(pages) != 2 || pages[0] != want {
+		t.Errorf("Paginate() =\n%v\nwant\n%v", pages, want)
+	}
+}
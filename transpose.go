@@ -0,0 +1,323 @@
+// -*- coding: utf-8 -*-
+// transpose.go
+// -----------------------------------------------------------------------------
+//
+// Started on <sáb 17-10-2026 02:05:04 (1792202704)>
+//
+
+package table
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// ----------------------------------------------------------------------------
+// Transpose
+// ----------------------------------------------------------------------------
+
+// Functions
+// ----------------------------------------------------------------------------
+
+// -- Private
+
+// return the horizontal alignment ('l', 'c' or 'r') of contents with the given
+// vertical alignment once they are transposed
+func getTransposedHAlign(valign byte) byte {
+
+	switch unicode.ToLower(rune(valign)) {
+	case 'c':
+		return 'c'
+	case 'b':
+		return 'r'
+	}
+	return 'l'
+}
+
+// return the vertical alignment ('t', 'c' or 'b') of contents with the given
+// horizontal alignment once they are transposed. Numbers aligned on the
+// decimal point are aligned to the bottom, and paragraphs according to their
+// alignment
+func getTransposedVAlign(halign byte) byte {
+
+	switch unicode.ToLower(rune(halign)) {
+	case 'c':
+		return 'c'
+	case 'r', 'd':
+		return 'b'
+	}
+	return 't'
+}
+
+// return the horizontal rule drawn with the first vertical separator found in
+// the given string and true, or false if it contains none
+func getTransposedSeparator(sep string) (hrule, bool) {
+
+	for _, r := range sep {
+		switch r {
+		case vertical_single:
+			return hrule(horizontal_single), true
+		case vertical_double:
+			return hrule(horizontal_double), true
+		case vertical_thick:
+			return hrule(horizontal_thick), true
+		}
+	}
+	return "", false
+}
+
+// Methods
+// ----------------------------------------------------------------------------
+
+// -- Private
+
+// return the vertical separator used to draw the given horizontal rule once it
+// is transposed and true, or false if it is not drawn over all columns with
+// the same rune
+func (t *Table) getTransposedRule(irow int) (rune, bool) {
+
+	rule := t.cells[irow][0].(hrule)
+	for j := 1; j < t.GetNbColumns(); j++ {
+		if t.cells[irow][j].(hrule) != rule {
+			return 0, false
+		}
+	}
+	switch rule {
+	case hrule(horizontal_single):
+		return vertical_single, true
+	case hrule(horizontal_double):
+		return vertical_double, true
+	case hrule(horizontal_thick):
+		return vertical_thick, true
+	}
+	return 0, false
+}
+
+// return the columns of a transposed table with the given data rows where the
+// horizontal rule obtained from the separator of the jcol-th column is drawn,
+// i.e., all of them but those taken by multicells which span the separator.
+// Columns are given in pairs as in addRule
+func (t *Table) getTransposedRuleColumns(data []int, jcol int) []int {
+
+	var cols []int
+	for k, i := range data {
+		if m := t.getMulticell(i, jcol); m != nil && m.getColumnInit() < jcol {
+			continue
+		}
+		if len(cols) > 0 && cols[len(cols)-1] == k {
+			cols[len(cols)-1] = k + 1
+		} else {
+			cols = append(cols, k, k+1)
+		}
+	}
+
+	// if the separator is spanned in all columns, then the rule is blank
+	if len(cols) == 0 {
+		return []int{0, 0}
+	}
+	return cols
+}
+
+// return the item to add to a transposed table to show the cell in the given
+// location. Its alignment is transposed, so that it is given as a Cell unless
+// it is shown at the top left corner. Values shown with a formatter are given
+// with their text, and contents of paragraphs are given already split in lines
+// of the width of their column. Contents of truncated columns are truncated to
+// the same width
+func (t *Table) getTransposedCell(irow, jcol int) any {
+
+	col := t.columns[jcol]
+	halign, valign := col.hformat.alignment, col.vformat.alignment
+
+	var result Cell
+	switch c := t.cells[irow][jcol].(type) {
+	case cell:
+		result = Cell{Value: c.data, Style: c.ansi, PadLeft: c.padleft, PadRight: c.padright, Truncate: c.truncate}
+		if c.halign != 0 {
+			halign = c.halign
+		}
+		if c.valign != 0 {
+			valign = c.valign
+		}
+	case value:
+		result = Cell{Value: c.data}
+	default:
+		result = Cell{Value: getCellContents(c)}
+	}
+	if col.format != nil {
+		result.Value = getCellContents(t.cells[irow][jcol])
+	}
+	switch {
+	case col.hformat.isParagraph():
+		lines := splitParagraph(getCellContents(t.cells[irow][jcol]), col.hformat.arg)
+		result.Value = strings.Join(lines, "\n")
+	case col.hformat.isTruncated() && result.Truncate == 0:
+		result.Truncate = col.hformat.arg
+	}
+
+	// cells aligned to the top left corner are given with no format, unless
+	// they had their own format
+	if result.HAlign = getTransposedHAlign(valign); result.HAlign == 'l' {
+		result.HAlign = 0
+	}
+	if result.VAlign = getTransposedVAlign(halign); result.VAlign == 't' {
+		result.VAlign = 0
+	}
+	if result.HAlign == 0 && result.VAlign == 0 && result.Style == "" &&
+		result.PadLeft == 0 && result.PadRight == 0 && result.Truncate == 0 {
+		return result.Value
+	}
+	return result
+}
+
+// return a new table which is the transposition of this one. All its columns
+// have to be shown
+func (t *Table) transpose() (*Table, error) {
+
+	// the data rows of this table become the columns of the new one, and the
+	// horizontal rules drawn before them are transposed into vertical
+	// separators, if possible
+	var data []int
+	seps := []string{""}
+	for i := range t.cells {
+		if t.isRule(i) {
+			if sep, ok := t.getTransposedRule(i); ok {
+				seps[len(seps)-1] += string(sep)
+			}
+			continue
+		}
+		data = append(data, i)
+		seps = append(seps, "")
+	}
+	if len(data) == 0 || t.GetNbColumns() == 0 {
+		return &Table{}, errors.New("Tables with no data can not be transposed")
+	}
+	var spec string
+	for k := range data {
+		switch {
+		case seps[k] != "" && k == 0:
+			spec += seps[k] + " "
+		case seps[k] != "":
+			spec += " " + seps[k] + " "
+		case k > 0:
+			spec += " "
+		}
+		spec += "l"
+	}
+	if seps[len(data)] != "" {
+		spec += " " + seps[len(data)]
+	}
+	result, err := NewTable(spec)
+	if err != nil {
+		return &Table{}, err
+	}
+	result.theme, result.maxwidth, result.ellipsis = t.theme, t.maxwidth, t.ellipsis
+
+	// every column of this table becomes a row of the new one, and vertical
+	// separators are transposed into horizontal rules, if possible
+	for j := 0; j < t.GetNbColumns(); j++ {
+		if rule, ok := getTransposedSeparator(t.columns[j].sep); ok {
+			if err := result.addRule(rule, t.getTransposedRuleColumns(data, j)...); err != nil {
+				return &Table{}, err
+			}
+		}
+
+		var cells []any
+		for k, i := range data {
+
+			// locations taken by multicells are skipped, and multicells are
+			// transposed where they start
+			if m := t.getMulticell(i, j); m != nil {
+				if m.getRowInit() != i || m.getColumnInit() != j {
+					continue
+				}
+				switch {
+				case m.table.GetNbColumns() != 1:
+					return &Table{}, fmt.Errorf("The multicell in location (%v, %v) splits columns and can not be transposed", i, j)
+				case len(m.args) == 0:
+					return &Table{}, fmt.Errorf("The multicell in location (%v, %v) has no contents and can not be transposed", i, j)
+				case len(m.args) > 1:
+					return &Table{}, fmt.Errorf("The multicell in location (%v, %v) splits rows and can not be transposed", i, j)
+				}
+
+				// multicells span over the columns and rows obtained from
+				// the rows and columns they took, including the horizontal
+				// rules obtained from their vertical separators
+				nbcolumns := 0
+				for _, idata := range data {
+					if idata >= m.getRowInit() && idata < m.getRowInit()+m.getNbRows() {
+						nbcolumns++
+					}
+				}
+				nbrows := m.getNbColumns()
+				for jcol := j + 1; jcol < j+m.getNbColumns(); jcol++ {
+					if _, ok := getTransposedSeparator(t.columns[jcol].sep); ok {
+						nbrows++
+					}
+				}
+				inner := m.table.columns[0]
+				cspec := result.columns[k].sep + string(getTransposedHAlign(inner.vformat.alignment))
+				rspec := string(getTransposedVAlign(inner.hformat.alignment))
+				transposed, err := NewMulticell(nbcolumns, nbrows, cspec, rspec, m.args[0])
+				if err != nil {
+					return &Table{}, err
+				}
+				cells = append(cells, transposed)
+				continue
+			}
+			cells = append(cells, t.getTransposedCell(i, j))
+		}
+		if err := result.AddRow(cells...); err != nil {
+			return &Table{}, err
+		}
+	}
+
+	// the last separator, if any, is transposed as well
+	if len(t.columns) > t.GetNbColumns() {
+		if rule, ok := getTransposedSeparator(t.columns[len(t.columns)-1].sep); ok {
+			if err := result.addRule(rule); err != nil {
+				return &Table{}, err
+			}
+		}
+	}
+	return result, nil
+}
+
+// -- Public
+
+// Transpose returns a new table where the rows of this one become columns and
+// vice versa. Horizontal rules drawn over all columns with the same rune
+// become vertical separators, and vertical separators become horizontal rules
+// drawn over all columns but those of the multirows which were multicolumns
+// spanning them; other rules and separators (e.g., partial rules or separators
+// with no vertical bar) are not transposed. Multicolumns become
+// multirows, and multirows become multicolumns which span over the columns
+// obtained from the data rows they took. Hidden columns are not transposed.
+//
+// The alignment of the contents is transposed as well: the vertical alignment
+// given in the row specification ('t', 'c' or 'b') becomes the horizontal
+// alignment ('l', 'c' or 'r'), and vice versa. Because the new table has one
+// row per column of this one, cells whose alignment is not the default one are
+// given as Cells. Values shown with the formatter of their column are given
+// with the text they show. Contents of paragraphs are split in lines with the
+// width of their column, and contents of truncated columns are truncated
+// likewise. Numbers aligned on the decimal point are not aligned anymore, as
+// they are shown in different columns.
+//
+// It returns an error if the table has no data, or any multicell has no
+// contents or splits either columns or rows, i.e., it has a specification with
+// more than one column or it is given more than one argument
+func (t *Table) Transpose() (*Table, error) {
+
+	if t.hasHiddenColumns() {
+		return t.getVisibleTable().transpose()
+	}
+	return t.transpose()
+}
+
+// Local Variables:
+// mode:go
+// fill-column:80
+// End:
@@ -0,0 +1,191 @@
+// -*- coding: utf-8 -*-
+// transpose_test.go
+// -----------------------------------------------------------------------------
+//
+// Started on <sáb 17-10-2026 02:05:04 (1792202704)>
+//
+
+package table
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTable_Transpose(t *testing.T) {
+
+	// multicells can not be shared among tables, so that the rows of every
+	// table are created every time
+	newTable := func() *Table {
+		table, _ := NewTable("│ l │ r │ c ║ l │", "tbct")
+		table.AddThickRule()
+		table.AddRow("Name", "Units", "Price", "Note")
+		table.AddSingleRule()
+		table.AddRow("tea", 2, Cell{Value: 1.5, PadLeft: 2}, "x")
+		table.AddRow("cake", Multicolumn(2, " │ c", "n/a"), "y")
+		table.AddRow(Multirow(2, "c", "milk"), 3, 4, "z")
+		table.AddRow(5, 6, "w")
+		table.AddSingleRule(0, 2)
+		table.AddDoubleRule()
+		return table
+	}
+
+	tests := []struct {
+		name    string
+		table   func() *Table
+		want    string
+		wantErr bool
+	}{
+		{name: "transpose",
+			table: newTable,
+			want: `┎───────┬─────────────────╖
+┃ Name  │ tea   cake milk ║
+┠───────┼─────────────────╢
+┃ Units │     2       3 5 ║
+┠───────┼─────── n/a ─────╢
+┃ Price │   1.5      4  6 ║
+┣═══════╪═════════════════╣
+┃ Note  │ x     y    z  w ║
+┖───────┴─────────────────╜`},
+		{name: "hidden",
+			table: func() *Table {
+				table := newTable()
+				table.HideColumn(1)
+				return table
+			},
+			want: `┎───────┬─────────────────╖
+┃ Name  │ tea   cake milk ║
+┠───────┼─────────────────╢
+┃ Price │   1.5 n/a  4  6 ║
+┣═══════╪═════════════════╣
+┃ Note  │ x     y    z  w ║
+┖───────┴─────────────────╜`},
+		{name: "separators",
+			table: func() *Table {
+				table, _ := NewTable("l c r")
+				table.AddRow("a", "b", "c")
+				table.AddRow(1, 2, 3)
+				return table
+			},
+			want: `a 1
+b 2
+c 3`},
+		{name: "spanned",
+			table: func() *Table {
+				table, _ := NewTable("│ l │ l │ l │")
+				table.AddSingleRule()
+				table.AddRow("a", "b", "c")
+				table.AddSingleRule()
+				table.AddRow(Multicolumn(2, "│ c │", "AB"), "C")
+				table.AddSingleRule()
+				return table
+			},
+			want: `┌───┬────┐
+│ a │    │
+├───┤ AB │
+│ b │    │
+├───┼────┤
+│ c │ C  │
+└───┴────┘`},
+		{name: "paragraphs",
+			table: func() *Table {
+				table, _ := NewTable("│ p{5} │ r{3} │")
+				table.AddSingleRule()
+				table.AddRow("a long text", "truncated")
+				table.AddSingleRule()
+				table.AddRow("b", 2)
+				table.AddSingleRule()
+				return table
+			},
+			want: `┌──────┬───┐
+│ a    │ b │
+│ long │   │
+│ text │   │
+├──────┼───┤
+│ tr…  │ 2 │
+└──────┴───┘`},
+		{name: "empty",
+			table: func() *Table {
+				table, _ := NewTable("| l |")
+				table.AddSingleRule()
+				return table
+			},
+			wantErr: true},
+		{name: "split",
+			table: func() *Table {
+				table, _ := NewTable("| l | l |")
+				table.AddRow(Multicolumn(1, "| c | c", "a", "b"), "c")
+				return table
+			},
+			wantErr: true},
+		{name: "no contents",
+			table: func() *Table {
+				table, _ := NewTable("| l | l |")
+				table.AddRow(Multicolumn(2, "| c |"))
+				return table
+			},
+			wantErr: true},
+		{name: "rows",
+			table: func() *Table {
+				table, _ := NewTable("| l | l |")
+				table.AddRow(Multicolumn(2, "| c |", "a", "b"))
+				return table
+			},
+			wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.table().Transpose()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Transpose() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if text := got.String(); text != tt.want {
+				t.Errorf("String() =\n%v\nwant\n%v", text, tt.want)
+			}
+		})
+	}
+}
+
+func TestTable_TransposeTwice(t *testing.T) {
+
+	table, _ := NewTable("│ l │ r │ c │", "tbc")
+	table.AddRow("Name", "Units", "Price")
+	table.AddDoubleRule()
+	table.AddRow("tea", 2, Cell{Value: 1.5, HAlign: 'l'})
+	table.AddRow(Multirow(2, "c", "milk"), 3, 4)
+	table.AddRow(5, 6)
+	table.AddSingleRule()
+
+	transposed, err := table.Transpose()
+	if err != nil {
+		t.Fatalf("Transpose() error = %v", err)
+	}
+	got, err := transposed.Transpose()
+	if err != nil {
+		t.Fatalf("Transpose() error = %v", err)
+	}
+
+	// transposing a table twice shows the same contents with the same
+	// alignment, though the column specification is not preserved
+	want := `│ Name │ Units │ Price │
+╞══════╪═══════╪═══════╡
+│ tea  │     2 │ 1.5   │
+│ milk │     3 │   4   │
+│      │     5 │   6   │
+└──────┴───────┴───────┘`
+	if text := got.String(); text != want {
+		t.Errorf("String() =\n%v\nwant\n%v", text, want)
+	}
+	info, _ := got.Cell(2, 1)
+	if !reflect.DeepEqual(info.Value, 2) {
+		t.Errorf("Value = %v, want 2", info.Value)
+	}
+}
+
+// Local Variables:
+// mode:go
+// fill-column:80
+// End:
@@ -0,0 +1,150 @@
+// -*- coding: utf-8 -*-
+// value.go
+// -----------------------------------------------------------------------------
+//
+// Started on <sáb 17-10-2026 01:56:05 (1792202165)>
+//
+
+package table
+
+import (
+	"fmt"
+)
+
+// ----------------------------------------------------------------------------
+// Value
+// ----------------------------------------------------------------------------
+
+// Functions
+// ----------------------------------------------------------------------------
+
+// -- Private
+
+// return the given value as a floating-point number and true if it is a number
+// of any numerical type, and false otherwise
+func toNumber(data any) (float64, bool) {
+
+	switch v := data.(type) {
+	case int:
+		return float64(v), true
+	case int8:
+		return float64(v), true
+	case int16:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case uint:
+		return float64(v), true
+	case uint8:
+		return float64(v), true
+	case uint16:
+		return float64(v), true
+	case uint32:
+		return float64(v), true
+	case uint64:
+		return float64(v), true
+	case float32:
+		return float64(v), true
+	case float64:
+		return v, true
+	}
+	return 0, false
+}
+
+// return the value given by the user to create the given cell, either directly
+// or with a Cell. Any other cell has no value at all
+func getCellValue(item formatter) any {
+
+	switch c := item.(type) {
+	case value:
+		return c.data
+	case cell:
+		return c.data
+	}
+	return nil
+}
+
+// Methods
+// ----------------------------------------------------------------------------
+
+// -- Private
+
+// return the text used to show the given value in the jcol-th column, which is
+// the output of its formatter if it has any, and the output of a Sprintf
+// operation otherwise
+func (t *Table) formatValue(jcol int, data any) content {
+
+	if format := t.columns[jcol].format; format != nil {
+		return content(format(data))
+	}
+	return content(fmt.Sprintf("%v", data))
+}
+
+// compute again the text of all values given by the user in the data rows of
+// the table with the formatter of their columns
+func (t *Table) formatValues() {
+
+	for i := range t.cells {
+		if t.isRule(i) {
+			continue
+		}
+		for j, item := range t.cells[i] {
+			switch c := item.(type) {
+			case value:
+				c.text = t.formatValue(j, c.data)
+				t.cells[i][j] = c
+			case cell:
+				c.text = t.formatValue(j, c.data)
+				t.cells[i][j] = c
+			}
+		}
+	}
+}
+
+// -- Public
+
+// Values are processed exactly as the contents used to show them
+func (v value) Process(t *Table, irow, jcol int) []formatter {
+	return v.text.Process(t, irow, jcol)
+}
+
+// Values are formatted exactly as the contents used to show them
+func (v value) Format(t *Table, irow, jcol int) string {
+	return v.text.Format(t, irow, jcol)
+}
+
+// ----------------------------------------------------------------------------
+// Table
+// ----------------------------------------------------------------------------
+
+// Methods
+// ----------------------------------------------------------------------------
+
+// -- Public
+
+// SetColumnFormatter sets the function used to show the values of the jcol-th
+// column. Tables retain the original values given to AddRow (either directly or
+// with a Cell) and, by default, they are shown with the output of a Sprintf
+// operation. If a formatter is given, the values of the column are shown with
+// the text it returns instead. Using nil restores the default behaviour. The
+// width of all columns and the height of all rows are updated accordingly.
+//
+// Multicells are not affected by the formatters of the columns they span. It
+// returns an error if the column does not exist
+func (t *Table) SetColumnFormatter(jcol int, format func(any) string) error {
+
+	if err := t.verifyColumnIndex(jcol, false); err != nil {
+		return err
+	}
+	t.columns[jcol].format = format
+	t.formatValues()
+	t.recompute()
+	return nil
+}
+
+// Local Variables:
+// mode:go
+// fill-column:80
+// End:
@@ -0,0 +1,145 @@
+// -*- coding: utf-8 -*-
+// value_test.go
+// -----------------------------------------------------------------------------
+//
+// Started on <sáb 17-10-2026 01:56:05 (1792202165)>
+//
+
+package table
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestTable_SetColumnFormatter(t *testing.T) {
+
+	euros := func(v any) string {
+		if x, ok := v.(float64); ok {
+			return fmt.Sprintf("%.2f €", x)
+		}
+		return fmt.Sprintf("%v", v)
+	}
+	upper := func(v any) string {
+		return fmt.Sprintf("<%v>", v)
+	}
+
+	tests := []struct {
+		name    string
+		jcol    int
+		format  func(any) string
+		before  bool
+		want    string
+		wantErr bool
+	}{
+		{name: "after",
+			jcol: 1, format: euros,
+			want: `┌──────┬─────────┐
+│ Item │   Price │
+├──────┼─────────┤
+│ tea  │  1.50 € │
+│ cake │ 12.00 € │
+└──────┴─────────┘`},
+		{name: "before",
+			jcol: 1, format: euros, before: true,
+			want: `┌──────┬─────────┐
+│ Item │   Price │
+├──────┼─────────┤
+│ tea  │  1.50 € │
+│ cake │ 12.00 € │
+└──────┴─────────┘`},
+		{name: "cell",
+			jcol: 0, format: upper,
+			want: `┌────────┬───────┐
+│ <Item> │ Price │
+├────────┼───────┤
+│ <tea>  │   1.5 │
+│ <cake> │    12 │
+└────────┴───────┘`},
+		{name: "reset",
+			jcol: 1, format: nil,
+			want: `┌──────┬───────┐
+│ Item │ Price │
+├──────┼───────┤
+│ tea  │   1.5 │
+│ cake │    12 │
+└──────┴───────┘`},
+		{name: "column",
+			jcol: 2, format: euros, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table, _ := NewTable("│ l │ r │")
+			if tt.before {
+				if err := table.SetColumnFormatter(tt.jcol, tt.format); err != nil {
+					t.Fatalf("SetColumnFormatter() error = %v", err)
+				}
+			}
+			table.AddSingleRule()
+			table.AddRow("Item", "Price")
+			table.AddSingleRule()
+			table.AddRow(Cell{Value: "tea"}, 1.5)
+			table.AddRow("cake", 12.0)
+			table.AddSingleRule()
+
+			if !tt.before {
+				err := table.SetColumnFormatter(tt.jcol, tt.format)
+				if (err != nil) != tt.wantErr {
+					t.Fatalf("SetColumnFormatter() error = %v, wantErr %v", err, tt.wantErr)
+				}
+				if err != nil {
+					return
+				}
+			}
+			if got := table.String(); got != tt.want {
+				t.Errorf("String() =\n%v\nwant\n%v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTable_Values(t *testing.T) {
+
+	table, _ := NewTable("| l | r |")
+	table.AddRow("Item", "Price")
+	table.AddSingleRule()
+	table.AddRow("tea", 1.5)
+	table.AddRow("cake", Cell{Value: 12, HAlign: 'l'})
+	table.AddRow("milk", 3)
+	table.SetColumnFormatter(1, func(v any) string {
+		if s, ok := v.(string); ok {
+			return s
+		}
+		return fmt.Sprintf("$%v", v)
+	})
+
+	// values are retained as they were given
+	var got []any
+	for _, i := range table.DataRows() {
+		info, _ := table.Cell(i, 1)
+		got = append(got, info.Value)
+	}
+	if want := []any{"Price", 1.5, 12, 3}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Value = %v, want %v", got, want)
+	}
+
+	// and numbers are sorted by their value even if their text is not a
+	// number
+	if err := table.SortBy(SortKey{Column: 1}); err != nil {
+		t.Fatalf("SortBy() error = %v", err)
+	}
+	want := `│ Item │ Price │
+├──────┼───────┤
+│ tea  │  $1.5 │
+│ milk │    $3 │
+│ cake │ $12   │`
+	if got := table.String(); got != want {
+		t.Errorf("String() =\n%v\nwant\n%v", got, want)
+	}
+}
+
+// Local Variables:
+// mode:go
+// fill-column:80
+// End: